@@ -1,51 +1,196 @@
 package cligame
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/ninesl/zombie-chickens/zcgame"
 )
 
-func RunGame() {
+// NightChoices are the automatic night answers given to every player of a
+// game played in the terminal.
+var NightChoices zcgame.PlayerNightChoices
+
+// Language is the language prompts and messages are shown in.
+var Language = zcgame.English
+
+// RecordPath is the file the game record is saved to after every turn, or
+// empty to not save one.
+var RecordPath string
+
+// RunGame plays a hot-seat game in the terminal using the player names from
+// the command line and the given optional rules. Solo games are saved to the
+// leaderboard file; daily is the DailyDate of a daily challenge, or empty.
+func RunGame(options zcgame.GameOptions, leaderboardPath string, daily string) {
 	names := flag.Args()
 	if len(names) < 1 {
-		log.Fatal("usage: go run . [-debug] name1 [name2 ...]")
+		log.Fatal("usage: go run . [-debug] [-expansion] [-zombies] [-bosses N] [-exhaust reshuffle|skip|end] [-max-nights N] [-coop] [-coop-nights N] [-teams 1,2,1,2] [-interaction] [-roles] [-reorganize] [-solo] [-daily] [-seed N] [-leaderboard FILE] [-campaign] [-level N] [-progress FILE] [-tutorial] [-setup deal|mulligan|draft] [-hand-size N] [-plays N] [-draws N] [-face-up N] [-lives N] [-night-cards rising|half|one] [-lang en|es] [-record FILE] [-replay FILE] [-auto-confirm] [-auto-defend] [-shield ask|always|never] [-spare-wolr] name1 [name2 ...]")
 	}
 
-	game, err := zcgame.CreateNewGame(names...)
+	game, err := zcgame.CreateNewGameWithOptions(options, names...)
 	if err != nil {
 		log.Fatal(err)
 	}
+	playGame(game)
+
+	if options.Mode == zcgame.ModeSolo {
+		lb, err := zcgame.RecordSoloGame(leaderboardPath, game, names[0], daily)
+		if err != nil {
+			log.Printf("could not save the leaderboard: %v", err)
+		} else {
+			fmt.Println(LeaderboardString(lb, daily))
+		}
+	}
+}
 
-	// Game loop
+// RunLevel plays Campaign[idx] in the terminal with the first player name from
+// the command line, and marks the level complete in the progress file if it is won.
+// A negative idx plays the next level not yet completed.
+func RunLevel(idx int, progressPath string) {
+	names := flag.Args()
+	if len(names) < 1 {
+		log.Fatal("usage: go run . -campaign [-level N] [-progress FILE] name")
+	}
+	progress, err := zcgame.LoadCampaignProgress(progressPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if idx < 0 {
+		idx = min(progress.NextLevel(), len(zcgame.Campaign)-1)
+	}
+	if idx >= len(zcgame.Campaign) {
+		log.Fatalf("there are only %d campaign levels", len(zcgame.Campaign))
+	}
+	if !progress.Unlocked(idx) {
+		log.Fatalf("level %d is locked; finish level %d first", idx+1, idx)
+	}
+
+	level := zcgame.Campaign[idx]
+	game, err := zcgame.CreateLevelGame(level, names[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	startLevel(fmt.Sprintf("Level %d: %s", idx+1, level.Name), level)
+	playGame(game)
+
+	progress, err = zcgame.RecordLevelResult(progressPath, game)
+	if err != nil {
+		log.Printf("could not save campaign progress: %v", err)
+	} else {
+		fmt.Println(CampaignString(progress))
+	}
+}
+
+// RunTutorial plays the guided tutorial game in the terminal with the first
+// player name from the command line.
+func RunTutorial() {
+	names := flag.Args()
+	if len(names) < 1 {
+		log.Fatal("usage: go run . -tutorial name")
+	}
+	game, err := zcgame.CreateTutorialGame(names[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	startLevel(zcgame.TutorialLevel.Name, zcgame.TutorialLevel)
+	playGame(game)
+}
+
+// RunReplay steps through the game record in the file, showing the board
+// before each move. Press Enter for the next move, or type c to skip to the
+// end. A record of an unfinished game is then played on from where it stopped.
+func RunReplay(path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	record, err := zcgame.ParseRecord(file)
+	file.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	stepping := true
+	game, err := record.Replay(func(game zcgame.GameView, move zcgame.Move) {
+		if !stepping {
+			return
+		}
+		RefreshRender(game)
+		fmt.Printf("%s\n(Enter for the next move, c to skip to the end) ", move)
+		if scanner.Scan() && strings.TrimSpace(scanner.Text()) == "c" {
+			stepping = false
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	RefreshRender(game)
+	if game.EndReason() != zcgame.GameNotOver {
+		fmt.Println(GameOverString(game))
+		return
+	}
+	playGame(game)
+}
+
+// startLevel shows a level's introduction and waits for the player to start.
+func startLevel(title string, level zcgame.Level) {
+	fmt.Println(LevelIntroString(title, level))
+	fmt.Print("Press Enter to start...")
+	bufio.NewScanner(os.Stdin).Scan()
+}
+
+// playGame runs the game loop until the game is over, then prints the result.
+// A game that is already waiting for input, such as a replayed one, picks up
+// at that prompt.
+func playGame(game zcgame.GameView) {
+	for i := range game.PlayerCount() {
+		game.SetNightChoices(i, NightChoices)
+	}
+	inputNeeded := game.PendingInputs()[game.ActiveInputPlayerIdx()]
 	for {
 		// Try to advance the game
-		gameContinues, inputNeeded := game.ContinueDay()
+		gameContinues := true
+		if inputNeeded == nil {
+			gameContinues, inputNeeded = game.ContinueDay()
+		}
 
 		if inputNeeded != nil {
-			// Gather input and continue
-			input := GatherInput(game, inputNeeded)
-
-			// Provide input and continue
-			for {
-				gameContinues, inputNeeded = game.ContinueAfterInput(input)
-				if inputNeeded == nil {
-					break
+			// Gather input and continue until no more is needed
+			for inputNeeded != nil {
+				switch {
+				case inputNeeded.SelectCount > 1:
+					gameContinues, inputNeeded = game.ContinueAfterInputs(GatherSelection(game, inputNeeded))
+				case inputNeeded.Context == zcgame.InputContextMove:
+					gameContinues, inputNeeded = GatherMove(game, inputNeeded)
+				default:
+					gameContinues, inputNeeded = game.ContinueAfterInput(GatherInput(game, inputNeeded))
 				}
-				input = GatherInput(game, inputNeeded)
 			}
 		}
 		RefreshRender(game)
+		saveRecord(game)
 
 		if gameContinues {
 			// Day completed successfully, show state and continue
 			continue
 		}
 
-		// Game over - all players eliminated
-		fmt.Println("GAME OVER - All players have been eliminated!")
+		fmt.Println(GameOverString(game))
 		break
 	}
 }
+
+// saveRecord writes the game's record to RecordPath, if one is set.
+func saveRecord(game zcgame.GameView) {
+	if RecordPath == "" {
+		return
+	}
+	if err := os.WriteFile(RecordPath, []byte(game.Record().String()), 0o644); err != nil {
+		log.Printf("could not save the game record: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ package cligame
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ninesl/zombie-chickens/zcgame"
 )
@@ -24,10 +25,13 @@ const (
 	BrightBlue   = "\033[94m"
 	BrightPurple = "\033[95m"
 	BrightGrey   = "\033[38;5;105m"
+	Cyan         = "\033[36m"
+	Pink         = "\033[38;5;213m"
+	White        = "\033[97m"
 )
 
-// PlayerColors for players 1-4
-var PlayerColors = []string{Green, Yellow, BrightPurple, BrightBlue}
+// PlayerColors for players 1-8
+var PlayerColors = []string{Green, Yellow, BrightPurple, BrightBlue, Cyan, Pink, Orange, White}
 
 // RedStar returns a red asterisk for one-time-use items
 func RedStar() string {
@@ -65,6 +69,22 @@ func FarmItemString(f zcgame.FarmItemType) string {
 		return BrightGrey + Italic + "Fuel" + Reset
 	case zcgame.WOLR:
 		return BrightGrey + Italic + "W.O.L.R" + Reset + RedStar()
+	case zcgame.Pitchfork:
+		return BrightGrey + Italic + "Pitchfork" + Reset
+	case zcgame.GuardDog:
+		return BrightGrey + Italic + "Guard Dog" + Reset
+	case zcgame.ElectricFence:
+		return BrightGrey + Italic + "Electric Fence" + Reset
+	case zcgame.Generator:
+		return BrightGrey + Italic + "Generator" + Reset
+	case zcgame.WornPitchfork:
+		return BrightGrey + Italic + "Worn Pitchfork" + Reset + RedStar()
+	case zcgame.Bait:
+		return BrightGrey + Italic + "Bait" + Reset + RedStar()
+	case zcgame.Sabotage:
+		return Orange + Italic + "Sabotage" + Reset
+	case zcgame.Trade:
+		return Orange + Italic + "Trade" + Reset
 	default:
 		return fmt.Sprintf("FarmItemType ERROR %d", int(f))
 	}
@@ -81,6 +101,8 @@ func TurnString(t zcgame.Turn) string {
 		return BrightPurple + Italic + "Night" + Reset
 	case zcgame.Day:
 		return Italic + "Day" + Reset
+	case zcgame.Setup:
+		return Italic + "Setup" + Reset
 	default:
 		return fmt.Sprintf("Turn ERROR %d", int(t))
 	}
@@ -103,25 +125,27 @@ func ZombieTraitString(zt zcgame.ZombieTrait) string {
 		return BrightGreen + "Timid" + Reset
 	case zcgame.Exploding:
 		return Orange + "Exploding" + Reset
+	case zcgame.Armored:
+		return BrightGrey + "Armored" + Reset
+	case zcgame.Swarm:
+		return Yellow + "Swarm" + Reset
+	case zcgame.Burrowing:
+		return Orange + "Burrowing" + Reset
+	case zcgame.Contagious:
+		return Green + "Contagious" + Reset
 	default:
 		return fmt.Sprintf("ZombieTrait ERROR %d", int(zt))
 	}
 }
 
-// StageInTurnString returns the CLI-formatted string for a StageInTurn with ANSI colors
-func StageInTurnString(s zcgame.StageInTurn) string {
-	switch s {
-	case zcgame.OptionalDiscard:
-		return Bold + Italic + "Discard a card to draw a card from the deck (optional)" + Reset
-	case zcgame.Play2Cards:
-		return Bold + Italic + "Play 2 cards to your farm" + Reset
-	case zcgame.Draw2Cards:
-		return Bold + Italic + "Draw 2 cards from the deck or the 2 face-up cards" + Reset
-	case zcgame.Nighttime:
-		return Bold + Italic + "Progress through the night..." + Reset
-	default:
+// StageInTurnString returns the CLI-formatted string for a StageInTurn with ANSI colors.
+// The rules give the number of cards played and drawn.
+func StageInTurnString(s zcgame.StageInTurn, rules zcgame.Rules) string {
+	text := zcgame.StageText(s, rules)
+	if text.IsZero() {
 		return fmt.Sprintf("StageInTurn ERROR %d", int(s))
 	}
+	return Bold + Italic + text.In(Language) + Reset
 }
 
 // EventString returns the CLI-formatted string for an Event with ANSI colors
@@ -143,6 +167,32 @@ func ZombieChickenString(name string, traits zcgame.ZombieTraits) string {
 	return fmt.Sprintf("%s%s%s\n%s", Bold, name, Reset, ZombieTraitsString(traits))
 }
 
+// ResolvedString returns a note for a zombie that has already attacked this night,
+// or the health left for a boss
+func ResolvedString(zc zcgame.ZombieChicken, resolved int) string {
+	if zc.IsBoss() {
+		return fmt.Sprintf("\n%sBoss%s: %d/%d health", Red, Reset, int(zc.Health)-resolved, zc.Health)
+	}
+	if resolved == 0 {
+		return ""
+	}
+	if zc.Traits.HasTrait(zcgame.Armored) {
+		return "\n" + Italic + "(armor cracked)" + Reset
+	}
+	if zc.Traits.HasTrait(zcgame.Swarm) {
+		return "\n" + Italic + "(second wave)" + Reset
+	}
+	return ""
+}
+
+// BossNightString returns a banner for boss nights, or an empty string on other turns
+func BossNightString(v zcgame.GameView) string {
+	if v.Turn() != zcgame.Night || !v.IsBossNight() {
+		return ""
+	}
+	return " " + Red + "- Boss Night!" + Reset
+}
+
 // StackString returns the CLI-formatted string for a Stack
 func StackString(s zcgame.Stack) string {
 	result := "{ "
@@ -240,6 +290,19 @@ func HandStringWithoutIndices(h zcgame.Hand) string {
 	return handStringWithIndices(h, false)
 }
 
+// HiddenHandString returns the CLI-formatted string for a Hand the viewer may not see
+func HiddenHandString(h zcgame.Hand) string {
+	return fmt.Sprintf("Hand: %d cards", h.Count())
+}
+
+// visibleHandString formats the hand with format, or hides it if it isn't shown
+func visibleHandString(h zcgame.Hand, showHand bool, format func(zcgame.Hand) string) string {
+	if !showHand {
+		return HiddenHandString(h)
+	}
+	return format(h)
+}
+
 func handStringWithIndices(h zcgame.Hand, showIndices bool) string {
 	result := "Hand: { "
 	first := true
@@ -263,9 +326,139 @@ func handStringWithIndices(h zcgame.Hand, showIndices bool) string {
 	return result
 }
 
-// PublicDayCardsString returns the CLI-formatted string for PublicDayCards
+// PublicDayCardsString returns the CLI-formatted string for PublicDayCards.
+// Empty slots (left when the day deck ran out) are not shown.
 func PublicDayCardsString(cards zcgame.PublicDayCards) string {
-	return StackString(zcgame.Stack(cards[:]))
+	stack := zcgame.Stack{}
+	for _, card := range cards {
+		if card != zcgame.NUM_FARM_ITEMS {
+			stack = append(stack, card)
+		}
+	}
+	return StackString(stack)
+}
+
+// PlayerNameWithTags returns the player's name, followed by their team in team
+// games and their role when roles are on
+func PlayerNameWithTags(v zcgame.GameView, pv zcgame.PlayerView) string {
+	name := pv.Name()
+	if v.Options().Mode == zcgame.ModeTeams {
+		name += fmt.Sprintf(" (Team %d)", pv.Team()+1)
+	}
+	if pv.Role() != zcgame.RoleNone {
+		name += " " + RoleString(pv.Role(), pv.RoleUsed())
+	}
+	return name
+}
+
+// RoleString returns the CLI-formatted role with its ability, greyed out once
+// a once-per-game power is spent
+func RoleString(role zcgame.Role, used bool) string {
+	if used {
+		return BrightGrey + Italic + fmt.Sprintf("[%s: used]", role) + Reset
+	}
+	return Orange + Italic + fmt.Sprintf("[%s: %s]", role, role.Description()) + Reset
+}
+
+// GameOverString returns the CLI-formatted game over message with the final standings
+func GameOverString(v zcgame.GameView) string {
+	result := fmt.Sprintf("GAME OVER - %s!", v.EndReason())
+	if v.Options().Mode == zcgame.ModeTeams {
+		for i, team := range v.TeamStandings() {
+			result += fmt.Sprintf("\n%d. Team %d (%s) : %dhp", i+1, team.Team+1, strings.Join(team.Players, ", "), team.Lives)
+		}
+		return result
+	}
+	if level := v.Level(); level != nil {
+		return result + "\n" + LevelGoalString(*level, v.LevelProgress())
+	}
+	if v.Options().Mode == zcgame.ModeSolo {
+		return result + "\n" + SoloScoreString(v.SoloScore()) + fmt.Sprintf("\nSeed: %d", v.Seed())
+	}
+	standings := v.Standings()
+	for i, pv := range standings {
+		result += fmt.Sprintf("\n%d. %s : %dhp", i+1, ColorPlayerName(pv.Name(), v.PlayerIdxByID(pv.ID())), pv.Lives())
+	}
+	// Eliminated players follow, the last one out first
+	eliminated := v.Eliminated()
+	for i := len(eliminated) - 1; i >= 0; i-- {
+		result += "\n" + fmt.Sprintf("%d. ", len(standings)+len(eliminated)-i) + EliminationString(eliminated[i])
+	}
+	return result
+}
+
+// EliminationString returns the CLI-formatted final stats of an eliminated player
+func EliminationString(pv zcgame.PlayerView) string {
+	out, _ := pv.Elimination()
+	result := fmt.Sprintf("%s : out on night %d", pv.Name(), out.Night)
+	if out.Cause != "" {
+		result += fmt.Sprintf(" (%s)", out.Cause)
+	}
+	return result + fmt.Sprintf(", %d zombies killed", pv.ZombiesKilled())
+}
+
+// SoloScoreString returns the CLI-formatted breakdown of a solo score
+func SoloScoreString(s zcgame.SoloScore) string {
+	return fmt.Sprintf("Nights survived: %d | Zombies killed: %d | Cards left: %d\n%sScore: %d%s",
+		s.Nights, s.Kills, s.CardsLeft, Bold, s.Total, Reset)
+}
+
+// LeaderboardString returns the CLI-formatted top 10 solo scores, limited to
+// the daily challenge when daily is set
+func LeaderboardString(lb *zcgame.Leaderboard, daily string) string {
+	result := "--- Leaderboard ---"
+	if daily != "" {
+		result = fmt.Sprintf("--- Daily Challenge %s ---", daily)
+	}
+	for i, entry := range lb.Top(10, daily) {
+		result += fmt.Sprintf("\n%d. %s : %d (%d nights, %d kills, %d cards)", i+1, entry.Name, entry.Score.Total, entry.Score.Nights, entry.Score.Kills, entry.Score.CardsLeft)
+	}
+	return result
+}
+
+// LevelIntroString returns the CLI-formatted introduction shown before a
+// campaign level or the tutorial starts
+func LevelIntroString(title string, level zcgame.Level) string {
+	return fmt.Sprintf("%s%s%s\n%s\n%sGoal: %s%s", Bold, title, Reset, level.Intro, Orange, level.Goal, Reset)
+}
+
+// TipString returns the CLI-formatted tutorial tip shown above a prompt
+func TipString(tip string) string {
+	return Cyan + Bold + "TIP: " + Reset + Cyan + tip + Reset
+}
+
+// LevelGoalString returns the CLI-formatted goal of a campaign level and how
+// far it has been met
+func LevelGoalString(level zcgame.Level, progress zcgame.LevelProgress) string {
+	result := fmt.Sprintf("%s: %s (", level.Name, level.Goal)
+	parts := []string{}
+	if level.Goal.Kills > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d killed", progress.Kills, level.Goal.Kills))
+	}
+	if level.Goal.Nights > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d nights", progress.Nights, level.Goal.Nights))
+	}
+	if level.Goal.Lives > 0 {
+		parts = append(parts, fmt.Sprintf("%dhp", progress.Lives))
+	}
+	return result + strings.Join(parts, ", ") + ")"
+}
+
+// CampaignString returns the CLI-formatted list of campaign levels, marking
+// completed and locked levels
+func CampaignString(progress *zcgame.CampaignProgress) string {
+	result := "--- Campaign ---"
+	for i, level := range zcgame.Campaign {
+		status := ""
+		switch {
+		case progress.IsComplete(level.ID):
+			status = Green + " [complete]" + Reset
+		case !progress.Unlocked(i):
+			status = BrightGrey + " [locked]" + Reset
+		}
+		result += fmt.Sprintf("\n%d. %s%s", i+1, level.Name, status)
+	}
+	return result
 }
 
 // NightCardsString returns the CLI-formatted string for NightCards with visibility control
@@ -289,31 +482,31 @@ func NightCardsString(cards zcgame.NightCards, isCurrentPlayer bool, turn zcgame
 
 	if card.IsZombie() {
 		zc := zcgame.ZombieChickens[card.ZombieKey]
-		return fmt.Sprintf("%s\n%s", countStr, ZombieChickenString(zc.Name, zc.Traits))
+		return fmt.Sprintf("%s\n%s%s", countStr, ZombieChickenString(zc.Name, zc.Traits), ResolvedString(zc, card.Resolved))
 	} else if card.IsEvent() {
-		return fmt.Sprintf("%s\n%s", countStr, EventString(card.Event.Name, card.Event.Description))
+		return fmt.Sprintf("%s\n%s", countStr, EventString(card.Event.Name, card.Event.DescriptionText().In(Language)))
 	}
 
 	return ""
 }
 
 // PlayerString returns the CLI-formatted string for a Player
-func PlayerString(name string, lives int, nightCards zcgame.NightCards, stacks zcgame.Stacks, hand zcgame.Hand, isCurrentPlayer bool, turn zcgame.Turn, playerIdx int) string {
+func PlayerString(name string, lives int, nightCards zcgame.NightCards, stacks zcgame.Stacks, hand zcgame.Hand, showHand bool, isCurrentPlayer bool, turn zcgame.Turn, playerIdx int) string {
 	nightCardsStr := NightCardsString(nightCards, isCurrentPlayer, turn)
 	coloredName := ColorPlayerName(name, playerIdx)
-	return fmt.Sprintf("%s : %dhp\n%s\n%s\n%s", coloredName, lives, nightCardsStr, FarmString(stacks), HandString(hand))
+	return fmt.Sprintf("%s : %dhp\n%s\n%s\n%s", coloredName, lives, nightCardsStr, FarmString(stacks), visibleHandString(hand, showHand, HandString))
 }
 
 // PlayerStringForDiscard returns the CLI-formatted string for a Player during discard events
-func PlayerStringForDiscard(name string, lives int, nightCards zcgame.NightCards, stacks zcgame.Stacks, hand zcgame.Hand, isCurrentPlayer bool, turn zcgame.Turn, playerIdx int) string {
+func PlayerStringForDiscard(name string, lives int, nightCards zcgame.NightCards, stacks zcgame.Stacks, hand zcgame.Hand, showHand bool, isCurrentPlayer bool, turn zcgame.Turn, playerIdx int) string {
 	nightCardsStr := NightCardsString(nightCards, isCurrentPlayer, turn)
 	coloredName := ColorPlayerName(name, playerIdx)
-	return fmt.Sprintf("%s : %dhp\n%s\n%s\n%s", coloredName, lives, nightCardsStr, FarmStringForDiscard(stacks), HandStringWithoutIndices(hand))
+	return fmt.Sprintf("%s : %dhp\n%s\n%s\n%s", coloredName, lives, nightCardsStr, FarmStringForDiscard(stacks), visibleHandString(hand, showHand, HandStringWithoutIndices))
 }
 
 // PlayerStringForNight returns the CLI-formatted string for a Player during night phase
-func PlayerStringForNight(name string, lives int, nightCards zcgame.NightCards, stacks zcgame.Stacks, hand zcgame.Hand, isCurrentPlayer bool, turn zcgame.Turn, playerIdx int) string {
+func PlayerStringForNight(name string, lives int, nightCards zcgame.NightCards, stacks zcgame.Stacks, hand zcgame.Hand, showHand bool, isCurrentPlayer bool, turn zcgame.Turn, playerIdx int) string {
 	nightCardsStr := NightCardsString(nightCards, isCurrentPlayer, turn)
 	coloredName := ColorPlayerName(name, playerIdx)
-	return fmt.Sprintf("%s : %dhp\n%s\n%s\n%s", coloredName, lives, nightCardsStr, FarmStringForNight(stacks), HandStringWithoutIndices(hand))
+	return fmt.Sprintf("%s : %dhp\n%s\n%s\n%s", coloredName, lives, nightCardsStr, FarmStringForNight(stacks), visibleHandString(hand, showHand, HandStringWithoutIndices))
 }
@@ -3,8 +3,10 @@ package cligame
 import (
 	"bufio"
 	"fmt"
+	"maps"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/ninesl/zombie-chickens/zcgame"
 )
@@ -22,27 +24,27 @@ func intSliceChoices(s ...int) string {
 //   - RenderForDiscard: Farm item indices shown for event discards
 //   - RenderForNight: Stack indices shown for defense selection
 //   - RenderNone: No render, just the prompt
+//
+// During a day turn, entering "u" takes back the player's last decision and
+// asks the earlier prompt again.
 func GatherInput(v zcgame.GameView, inputNeeded *zcgame.PlayerInputNeeded) int {
-	// Render the appropriate game state
-	switch inputNeeded.RenderType {
-	case zcgame.RenderNormal:
-		RefreshRender(v)
-	case zcgame.RenderForDiscard:
-		refreshRenderForDiscard(v)
-	case zcgame.RenderForNight:
-		refreshRenderForNight(v)
-	case zcgame.RenderNone:
-		// No render
+	inputNeeded = inputNeeded.Localized(Language)
+	showPrompt(v, inputNeeded)
+	if v.CanUndo() {
+		fmt.Printf("%s (u to undo): ", inputNeeded.Message)
+	} else {
+		fmt.Printf("%s: ", inputNeeded.Message)
 	}
 
-	fmt.Printf("%s: ", inputNeeded.Message)
-
 	scanner := bufio.NewScanner(os.Stdin)
 	for {
 		if !scanner.Scan() {
 			continue
 		}
 		text := scanner.Text()
+		if text == "u" && v.CanUndo() {
+			return GatherInput(v, v.Undo())
+		}
 		input, err := strconv.Atoi(text)
 		if err != nil {
 			fmt.Printf("ERROR, retry input: %s\n", intSliceChoices(inputNeeded.ValidChoices...))
@@ -57,3 +59,100 @@ func GatherInput(v zcgame.GameView, inputNeeded *zcgame.PlayerInputNeeded) int {
 		fmt.Printf("ERROR, retry input: %s\n", intSliceChoices(inputNeeded.ValidChoices...))
 	}
 }
+
+// GatherSelection is GatherInput for multi-select prompts (SelectCount > 1).
+// The player enters all of their choices on one line, separated by spaces or
+// commas, and they are validated together.
+func GatherSelection(v zcgame.GameView, inputNeeded *zcgame.PlayerInputNeeded) []int {
+	inputNeeded = inputNeeded.Localized(Language)
+	showPrompt(v, inputNeeded)
+	fmt.Printf("%s (pick %d, e.g. 1 3): ", inputNeeded.Message, inputNeeded.SelectCount)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		if !scanner.Scan() {
+			continue
+		}
+		fields := strings.Fields(strings.ReplaceAll(scanner.Text(), ",", " "))
+		choices := make([]int, 0, len(fields))
+		for _, field := range fields {
+			choice, err := strconv.Atoi(field)
+			if err != nil {
+				break
+			}
+			choices = append(choices, choice)
+		}
+		if len(choices) == len(fields) && inputNeeded.ValidSelection(choices) {
+			return choices
+		}
+		fmt.Printf("ERROR, pick %d different numbers from: %s\n", inputNeeded.SelectCount, intSliceChoices(inputNeeded.ValidChoices...))
+	}
+}
+
+// GatherMove is GatherInput for moving a farm card (InputContextMove). It lists
+// each card that can move with the stack it is in, then each stack the chosen
+// card can go to, and answers both prompts. Returns like ContinueAfterInput.
+func GatherMove(v zcgame.GameView, inputNeeded *zcgame.PlayerInputNeeded) (bool, *zcgame.PlayerInputNeeded) {
+	card := withLabels(inputNeeded)
+	flatIdx := 0
+	for i, stack := range v.Player(v.ActiveInputPlayerIdx()).Stacks() {
+		for _, item := range stack {
+			flatIdx++
+			card.ChoiceLabels[flatIdx] = zcgame.NewText(zcgame.MsgMoveFrom, item, i+1).In(Language) + " " + StackString(stack)
+		}
+	}
+	gameContinues, next := v.ContinueAfterInput(GatherInput(v, card))
+	if next == nil || next.Context != zcgame.InputContextPlayCard {
+		return gameContinues, next
+	}
+
+	target := withLabels(next)
+	target.ChoiceLabels[0] = zcgame.NewText(zcgame.MsgMoveNewStack).In(Language)
+	for i, stack := range v.Player(v.ActiveInputPlayerIdx()).Stacks() {
+		target.ChoiceLabels[i+1] = zcgame.NewText(zcgame.MsgMoveTo, i+1).In(Language) + " " + StackString(stack)
+	}
+	return v.ContinueAfterInput(GatherInput(v, target))
+}
+
+// withLabels returns a copy of the input in Language whose ChoiceLabels can
+// take more labels without being rebuilt by GatherInput.
+func withLabels(input *zcgame.PlayerInputNeeded) *zcgame.PlayerInputNeeded {
+	input = input.Localized(Language)
+	input.LabelTexts = nil
+	input.ChoiceLabels = maps.Clone(input.ChoiceLabels)
+	if input.ChoiceLabels == nil {
+		input.ChoiceLabels = map[int]string{}
+	}
+	return input
+}
+
+// showPrompt renders the game state for the prompt and lists its labelled
+// choices and tip.
+func showPrompt(v zcgame.GameView, inputNeeded *zcgame.PlayerInputNeeded) {
+	// Render the appropriate game state
+	switch inputNeeded.RenderType {
+	case zcgame.RenderNormal:
+		RefreshRender(v)
+	case zcgame.RenderForDiscard:
+		refreshRenderForDiscard(v)
+	case zcgame.RenderForNight:
+		refreshRenderForNight(v)
+	case zcgame.RenderNone:
+		// No render
+	}
+
+	// Labelled choices (e.g. picking a teammate) are listed before the prompt
+	for _, choice := range inputNeeded.ValidChoices {
+		label, ok := inputNeeded.ChoiceLabels[choice]
+		if !ok {
+			continue
+		}
+		if inputNeeded.Context == zcgame.InputContextChoosePlayer && choice > 0 {
+			label = ColorPlayerName(label, choice-1)
+		}
+		fmt.Printf("%d: %s\n", choice, label)
+	}
+	if inputNeeded.Tip != "" {
+		fmt.Println(TipString(inputNeeded.Tip))
+	}
+}
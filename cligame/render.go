@@ -50,7 +50,11 @@ func statsString(v zcgame.GameView) string {
 		dayCardsDiscarded += count
 	}
 
-	return fmt.Sprintf("Zombies Killed: %d | Events Played: %d | Day Cards Discarded: %d", zombiesKilled, eventsPlayed, dayCardsDiscarded)
+	result := fmt.Sprintf("Zombies Killed: %d | Events Played: %d | Day Cards Discarded: %d", zombiesKilled, eventsPlayed, dayCardsDiscarded)
+	if level := v.Level(); level != nil {
+		result += "\n" + LevelGoalString(*level, v.LevelProgress())
+	}
+	return result
 }
 
 // gameString returns the CLI-formatted game state
@@ -62,12 +66,12 @@ func gameString(v zcgame.GameView) string {
 	result := fmt.Sprintf("%s %d\n%s\n---\n", TurnString(turn), v.NightNum(), PublicDayCardsString(v.PublicDayCards()))
 	for i, pv := range players {
 		isCurrentPlayer := i == currentPlayerIdx
-		result += PlayerString(pv.Name(), pv.Lives(), pv.NightCards(), pv.Stacks(), pv.Hand(), isCurrentPlayer, turn, i)
+		result += PlayerString(PlayerNameWithTags(v, pv), pv.Lives(), pv.NightCards(), pv.Stacks(), pv.Hand(), v.HandVisibleTo(v.ActiveInputPlayerIdx(), i), isCurrentPlayer, turn, i)
 		if i < len(players)-1 {
 			result += "\n---\n"
 		}
 	}
-	result += fmt.Sprintf("\n---\n%s\n", StageInTurnString(v.StageInTurn()))
+	result += fmt.Sprintf("\n---\n%s\n", StageInTurnString(v.StageInTurn(), v.Options().Rules))
 	return result
 }
 
@@ -101,12 +105,12 @@ func refreshRenderForDiscard(v zcgame.GameView) {
 	fmt.Printf("%s %d\n%s\n---\n", TurnString(turn), v.NightNum(), PublicDayCardsString(v.PublicDayCards()))
 	for i, pv := range players {
 		isCurrentPlayer := i == currentPlayerIdx
-		fmt.Printf("%s", PlayerStringForDiscard(pv.Name(), pv.Lives(), pv.NightCards(), pv.Stacks(), pv.Hand(), isCurrentPlayer, turn, i))
+		fmt.Printf("%s", PlayerStringForDiscard(PlayerNameWithTags(v, pv), pv.Lives(), pv.NightCards(), pv.Stacks(), pv.Hand(), v.HandVisibleTo(v.ActiveInputPlayerIdx(), i), isCurrentPlayer, turn, i))
 		if i < len(players)-1 {
 			fmt.Printf("\n---\n")
 		}
 	}
-	fmt.Printf("\n---\n%s\n", StageInTurnString(v.StageInTurn()))
+	fmt.Printf("\n---\n%s\n", StageInTurnString(v.StageInTurn(), v.Options().Rules))
 	if v.PlayerCount() > 0 {
 		fmt.Printf("%s's %s\n", ColorPlayerName(v.CurrentPlayer().Name(), v.CurrentPlayerIdx()), TurnString(turn))
 	}
@@ -121,15 +125,15 @@ func refreshRenderForNight(v zcgame.GameView) {
 
 	clearScreen()
 	fmt.Printf("%s\n", statsString(v))
-	fmt.Printf("%s %d\n%s\n---\n", TurnString(turn), v.NightNum(), PublicDayCardsString(v.PublicDayCards()))
+	fmt.Printf("%s %d%s\n%s\n---\n", TurnString(turn), v.NightNum(), BossNightString(v), PublicDayCardsString(v.PublicDayCards()))
 	for i, pv := range players {
 		isCurrentPlayer := i == currentPlayerIdx
-		fmt.Printf("%s", PlayerStringForNight(pv.Name(), pv.Lives(), pv.NightCards(), pv.Stacks(), pv.Hand(), isCurrentPlayer, turn, i))
+		fmt.Printf("%s", PlayerStringForNight(PlayerNameWithTags(v, pv), pv.Lives(), pv.NightCards(), pv.Stacks(), pv.Hand(), v.HandVisibleTo(v.ActiveInputPlayerIdx(), i), isCurrentPlayer, turn, i))
 		if i < len(players)-1 {
 			fmt.Printf("\n---\n")
 		}
 	}
-	fmt.Printf("\n---\n%s\n", StageInTurnString(v.StageInTurn()))
+	fmt.Printf("\n---\n%s\n", StageInTurnString(v.StageInTurn(), v.Options().Rules))
 	if v.PlayerCount() > 0 {
 		fmt.Printf("%s's %s\n", ColorPlayerName(v.CurrentPlayer().Name(), v.CurrentPlayerIdx()), TurnString(turn))
 	}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"flag"
+	"log"
+	"time"
 
 	"github.com/ninesl/zombie-chickens/cligame"
 	"github.com/ninesl/zombie-chickens/webapp"
@@ -11,13 +13,124 @@ import (
 func main() {
 	web := flag.Bool("web", false, "run web server instead of CLI game")
 	debug := flag.Bool("debug", false, "put events on top of night deck for testing")
+	expansion := flag.Bool("expansion", false, "add the defense expansion pack to the day deck (CLI only)")
+	zombies := flag.Bool("zombies", false, "add the zombie expansion to the night deck (CLI only)")
+	bosses := flag.Int("bosses", 0, "every Nth night each player also faces a boss, 0 for none (CLI only)")
+	exhaust := flag.String("exhaust", "reshuffle", "what happens when a deck runs out: reshuffle, skip or end (CLI only)")
+	maxNights := flag.Int("max-nights", 0, "end the game after this night, most lives wins, 0 for no limit (CLI only)")
+	coop := flag.Bool("coop", false, "play co-op: shared lives, card gifts and lent defenses (CLI only)")
+	coopNights := flag.Int("coop-nights", zcgame.DefaultCoopNights, "nights a co-op team must survive to win (CLI only)")
+	interaction := flag.Bool("interaction", false, "add Bait, Sabotage and Trade interaction cards to the day deck (CLI only)")
+	roles := flag.Bool("roles", false, "deal every player a farmer role with a special ability (CLI only)")
+	reorganize := flag.Bool("reorganize", false, "let players spend a play moving a card between their farm stacks (CLI only)")
+	solo := flag.Bool("solo", false, "play a scored solo game (CLI only)")
+	daily := flag.Bool("daily", false, "play today's solo daily challenge; other rule flags are ignored (CLI only)")
+	seed := flag.Int64("seed", 0, "seed the shuffles to replay the same decks, 0 for random (CLI only)")
+	leaderboard := flag.String("leaderboard", "leaderboard.json", "file solo scores are saved to (CLI only)")
+	campaign := flag.Bool("campaign", false, "play the next campaign level, or the one chosen with -level (CLI only)")
+	level := flag.Int("level", 0, "campaign level to play, 0 for the next one; implies -campaign (CLI only)")
+	progress := flag.String("progress", "campaign.json", "file campaign progress is saved to (CLI only)")
+	tutorial := flag.Bool("tutorial", false, "play the guided tutorial game (CLI only)")
+	setup := flag.String("setup", "deal", "how opening hands are dealt: deal, mulligan or draft (CLI only)")
+	autoConfirm := flag.Bool("auto-confirm", false, "skip night confirmations such as auto-kills and life losses (CLI only)")
+	autoDefend := flag.Bool("auto-defend", false, "defend with the cheapest stack that beats the zombie instead of asking (CLI only)")
+	shield := flag.String("shield", "ask", "when an exploding zombie attacks, use a Shield: ask, always or never (CLI only)")
+	spareWOLR := flag.Bool("spare-wolr", false, "never use a W.O.L.R. automatically with -auto-defend (CLI only)")
+	teams := flag.String("teams", "", "play in teams, one team number per player, e.g. 1,2,1,2 (CLI only)")
+	handSize := flag.Int("hand-size", zcgame.DefaultRules.HandSize, "cards in a full hand (CLI only)")
+	plays := flag.Int("plays", zcgame.DefaultRules.PlaysPerTurn, "cards played each day turn (CLI only)")
+	draws := flag.Int("draws", zcgame.DefaultRules.DrawsPerTurn, "cards drawn at the end of each day turn (CLI only)")
+	faceUp := flag.Int("face-up", zcgame.DefaultRules.PublicCards, "face-up cards available to draw, at most -draws (CLI only)")
+	lives := flag.Int("lives", 0, "starting lives per player, 0 to go by player count (CLI only)")
+	nightCards := flag.String("night-cards", "rising", "night cards dealt each night: rising (one per night), half (half the night plus one) or one (CLI only)")
+	lang := flag.String("lang", string(zcgame.English), "language of prompts and messages: en or es (CLI only)")
+	record := flag.String("record", "", "save the game record to this file after every turn (CLI only)")
+	replay := flag.String("replay", "", "step through a game record, then play on if it isn't over (CLI only)")
 	flag.Parse()
 
 	zcgame.DebugMode = *debug
+	shieldChoice, err := zcgame.ParseShieldChoice(*shield)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cligame.NightChoices = zcgame.PlayerNightChoices{
+		AutoConfirm: *autoConfirm,
+		AutoDefend:  *autoDefend,
+		Shield:      shieldChoice,
+		SpareWOLR:   *spareWOLR,
+	}
+	cligame.Language, err = zcgame.ParseLanguage(*lang)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cligame.RecordPath = *record
 
 	if *web {
 		webapp.RunServer()
+	} else if *replay != "" {
+		cligame.RunReplay(*replay)
+	} else if *tutorial {
+		cligame.RunTutorial()
+	} else if *campaign || *level > 0 {
+		cligame.RunLevel(*level-1, *progress)
 	} else {
-		cligame.RunGame()
+		deckExhaustion, err := zcgame.ParseDeckExhaustionRule(*exhaust)
+		if err != nil {
+			log.Fatal(err)
+		}
+		setupVariant, err := zcgame.ParseSetupVariant(*setup)
+		if err != nil {
+			log.Fatal(err)
+		}
+		nightCardCurve, err := zcgame.ParseNightCardCurve(*nightCards)
+		if err != nil {
+			log.Fatal(err)
+		}
+		mode := zcgame.ModeCompetitive
+		if *coop {
+			mode = zcgame.ModeCoop
+		}
+		var teamSeats []int
+		if *teams != "" {
+			mode = zcgame.ModeTeams
+			teamSeats, err = zcgame.ParseTeams(*teams)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		if *solo {
+			mode = zcgame.ModeSolo
+		}
+		options := zcgame.GameOptions{
+			DefenseExpansion: *expansion,
+			ZombieExpansion:  *zombies,
+			BossEvery:        *bosses,
+			DeckExhaustion:   deckExhaustion,
+			MaxNights:        *maxNights,
+			Mode:             mode,
+			CoopNights:       *coopNights,
+			Teams:            teamSeats,
+			InteractionCards: *interaction,
+			Roles:            *roles,
+			Reorganize:       *reorganize,
+			Seed:             *seed,
+			Setup:            setupVariant,
+			Rules: zcgame.Rules{
+				HandSize:      *handSize,
+				PlaysPerTurn:  *plays,
+				DrawsPerTurn:  *draws,
+				PublicCards:   *faceUp,
+				StartingLives: *lives,
+				NightCards:    nightCardCurve,
+			},
+		}
+		dailyDate := ""
+		if *daily {
+			now := time.Now()
+			options = zcgame.DailyChallenge(now)
+			dailyDate = zcgame.DailyDate(now)
+		}
+		cligame.RunGame(options, *leaderboard, dailyDate)
 	}
 }
@@ -11,6 +11,7 @@ import (
 // Config struct for describe configuration of the app.
 type Config struct {
 	Server *ServerConfig
+	Lobby  *LobbyConfig
 }
 
 type ServerConfig struct {
@@ -20,6 +21,12 @@ type ServerConfig struct {
 	IdleTimeout  time.Duration
 }
 
+type LobbyConfig struct {
+	MaxPlayers      int    // Most players that can join the lobby
+	LeaderboardPath string // File solo scores are saved to
+	ProgressPath    string // File campaign progress is saved to
+}
+
 var (
 	once     sync.Once
 	instance *Config
@@ -30,6 +37,9 @@ func NewConfig() *Config {
 	once.Do(func() {
 		// host := getEnv("SERVER_HOST", "localhost")
 		port := getEnvInt("SERVER_PORT", 8080)
+		maxPlayers := getEnvInt("LOBBY_MAX_PLAYERS", 8)
+		leaderboardPath := getEnv("LEADERBOARD_PATH", "leaderboard.json")
+		progressPath := getEnv("CAMPAIGN_PROGRESS_PATH", "campaign.json")
 		// readTimeout := getEnvInt("SERVER_READ_TIMEOUT", 30)
 		// WriteTimeout must be 0 for SSE connections to work properly
 		// SSE connections are long-lived and should not timeout
@@ -43,6 +53,11 @@ func NewConfig() *Config {
 				// WriteTimeout: time.Duration(writeTimeout) * time.Second,
 				// IdleTimeout:  time.Duration(idleTimeout) * time.Second,
 			},
+			Lobby: &LobbyConfig{
+				MaxPlayers:      maxPlayers,
+				LeaderboardPath: leaderboardPath,
+				ProgressPath:    progressPath,
+			},
 		}
 	})
 
@@ -15,21 +15,52 @@ const (
 
 // Action routes
 const (
-	LobbyJoin  = "/lobby/join"  // POST - join lobby with name
-	LobbyStart = "/lobby/start" // POST - start game (first player only)
-	GameInput  = "/game/input"  // POST - submit player choice
+	LobbyJoin    = "/lobby/join"    // POST - join lobby with name
+	LobbyStart   = "/lobby/start"   // POST - start game (first player only)
+	LobbyOptions = "/lobby/options" // POST - change game options (first player only)
+	GameInput    = "/game/input"    // POST - submit player choice
+	GameNight    = "/game/night"    // POST - change the player's night choices
+	GameUndo     = "/game/undo"     // POST - take back the player's last decision this turn
 )
 
 // SSE Event names
 const (
 	SSEEventLobby = "lobby"     // Lobby player list update
 	SSEEventGame  = "gamestate" // Full game board update
+	SSEEventTimer = "timer"     // Time left on the waiting prompts
 )
 
 // Form field names
 const (
-	FieldPlayerName = "player_name"
-	FieldChoice     = "choice"
+	FieldPlayerName       = "player_name"
+	FieldChoice           = "choice"
+	FieldDefenseExpansion = "defense_expansion"
+	FieldZombieExpansion  = "zombie_expansion"
+	FieldBossEvery        = "boss_every"
+	FieldDeckExhaustion   = "deck_exhaustion"
+	FieldMaxNights        = "max_nights"
+	FieldSetup            = "setup"
+	FieldSimultaneous     = "simultaneous_discards"
+	FieldHandSize         = "hand_size"
+	FieldPlays            = "plays_per_turn"
+	FieldDraws            = "draws_per_turn"
+	FieldPublicCards      = "public_cards"
+	FieldStartingLives    = "starting_lives"
+	FieldNightCards       = "night_cards"
+	FieldMode             = "mode"
+	FieldTeam             = "team"
+	FieldInteractionCards = "interaction_cards"
+	FieldRoles            = "roles"
+	FieldReorganize       = "reorganize"
+	FieldDaily            = "daily"
+	FieldLevel            = "level"
+	FieldTutorial         = "tutorial"
+	FieldAutoConfirm      = "auto_confirm"
+	FieldAutoDefend       = "auto_defend"
+	FieldShield           = "shield"
+	FieldSpareWOLR        = "spare_wolr"
+	FieldTurnTimer        = "turn_timer"
+	FieldLanguage         = "language"
 )
 
 // Element IDs for HTMX targeting
@@ -37,4 +68,8 @@ const (
 	IDLobbyContent = "lobby-content"
 	IDGameBoard    = "game-board"
 	IDPlayerList   = "player-list"
+	IDLobbyOptions = "lobby-options"
+	IDNightChoices = "night-choices"
+	IDSelection    = "selection" // Form the farm items of a multi-select prompt submit with
+	IDTurnTimer    = "turn-timer"
 )
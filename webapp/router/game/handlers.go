@@ -12,6 +12,7 @@ import (
 	"github.com/ninesl/zombie-chickens/webapp/state"
 	"github.com/ninesl/zombie-chickens/webapp/ui/components"
 	"github.com/ninesl/zombie-chickens/webapp/ui/pages"
+	"github.com/ninesl/zombie-chickens/zcgame"
 )
 
 // HandleGamePage serves the main game page
@@ -25,10 +26,10 @@ func HandleGamePage() http.HandlerFunc {
 			return
 		}
 
-		// Check player is in game
+		// Check player is in game - eliminated players stay to spectate
 		sessionID := middleware.GetSessionID(r.Context())
-		_, playerIdx := session.GetPlayerBySession(sessionID)
-		if playerIdx == -1 {
+		playerInfo, _ := session.GetPlayerBySession(sessionID)
+		if playerInfo == nil {
 			http.Redirect(w, r, endpoints.HomePage, http.StatusSeeOther)
 			return
 		}
@@ -56,12 +57,14 @@ func HandleGameConnect() http.HandlerFunc {
 			return
 		}
 
-		// Verify player is in game
-		_, playerIdx := session.GetPlayerBySession(sessionID)
-		if playerIdx == -1 {
+		// Verify player is in game; playerIdx is -1 once they are eliminated
+		playerInfo, playerIdx := session.GetPlayerBySession(sessionID)
+		if playerInfo == nil {
 			http.Error(w, "not in game", http.StatusForbidden)
 			return
 		}
+		// Prompts are shown in the browser's language unless the lobby picked one
+		session.SetBrowserLanguage(sessionID, r.Header.Get("Accept-Language"))
 
 		// Set SSE headers
 		w.Header().Set("Content-Type", "text/event-stream")
@@ -101,7 +104,7 @@ func HandleGameConnect() http.HandlerFunc {
 		}()
 
 		// Send initial state
-		initialData := renderGameBoard(session)
+		initialData := renderGameBoard(session, sessionID)
 		sseMsg := state.FormatSSE(endpoints.SSEEventGame, initialData)
 		if _, err := w.Write(sseMsg); err != nil {
 			log.Printf("[SSE] Error writing initial state: %v", err)
@@ -159,17 +162,31 @@ func HandleGameInput() http.HandlerFunc {
 			return
 		}
 
-		// Parse choice
-		choiceStr := r.FormValue(endpoints.FieldChoice)
-		choice, err := strconv.Atoi(choiceStr)
-		if err != nil {
+		// Parse choices - a multi-select prompt submits several
+		r.ParseForm()
+		choices := make([]int, 0, len(r.Form[endpoints.FieldChoice]))
+		for _, choiceStr := range r.Form[endpoints.FieldChoice] {
+			choice, err := strconv.Atoi(choiceStr)
+			if err != nil {
+				http.Error(w, "invalid choice", http.StatusBadRequest)
+				return
+			}
+			choices = append(choices, choice)
+		}
+		if len(choices) == 0 {
 			http.Error(w, "invalid choice", http.StatusBadRequest)
 			return
 		}
 
 		// Submit input
-		log.Printf("[INPUT] Player submitting choice: %d", choice)
-		err = session.SubmitInput(sessionID, choice)
+		var err error
+		if len(choices) == 1 {
+			log.Printf("[INPUT] Player submitting choice: %d", choices[0])
+			err = session.SubmitInput(sessionID, choices[0])
+		} else {
+			log.Printf("[INPUT] Player submitting choices: %v", choices)
+			err = session.SubmitSelection(sessionID, choices)
+		}
 		if err != nil {
 			log.Printf("[INPUT] Error: %v", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -179,9 +196,9 @@ func HandleGameInput() http.HandlerFunc {
 
 		// Broadcast updated state to all clients
 		ctx := context.Background()
-		session.BroadcastGame(ctx, func() []byte {
+		session.BroadcastGame(ctx, func(client *state.Client) []byte {
 			log.Printf("[INPUT] Rendering game board for broadcast...")
-			data := renderGameBoard(session)
+			data := renderGameBoard(session, client.SessionID)
 			log.Printf("[INPUT] Rendered %d bytes", len(data))
 			return state.FormatSSE(endpoints.SSEEventGame, data)
 		})
@@ -192,7 +209,72 @@ func HandleGameInput() http.HandlerFunc {
 	}
 }
 
-// renderGameBoard renders the game board HTML
-func renderGameBoard(session *state.GameSession) []byte {
-	return components.RenderGameBoard(session)
+// HandleGameUndo handles a player taking back their last decision
+func HandleGameUndo() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session := state.GetSession()
+		sessionID := middleware.GetSessionID(r.Context())
+
+		if err := session.Undo(sessionID); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Everyone sees the rollback
+		ctx := context.Background()
+		session.BroadcastGame(ctx, func(client *state.Client) []byte {
+			data := renderGameBoard(session, client.SessionID)
+			return state.FormatSSE(endpoints.SSEEventGame, data)
+		})
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// HandleGameNight handles a player changing how their night prompts are answered
+func HandleGameNight() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session := state.GetSession()
+		sessionID := middleware.GetSessionID(r.Context())
+
+		shield, err := zcgame.ParseShieldChoice(r.FormValue(endpoints.FieldShield))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		choices := zcgame.PlayerNightChoices{
+			AutoConfirm: r.FormValue(endpoints.FieldAutoConfirm) != "",
+			AutoDefend:  r.FormValue(endpoints.FieldAutoDefend) != "",
+			Shield:      shield,
+			SpareWOLR:   r.FormValue(endpoints.FieldSpareWOLR) != "",
+		}
+		if err := session.SetNightChoices(sessionID, choices); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Only this player's board shows their choices
+		w.Header().Set("Content-Type", "text/html")
+		components.NightChoicesForm(choices).Render(r.Context(), w)
+	}
+}
+
+// broadcastTurnTimer sends every client the time left on the waiting prompts,
+// or the whole board once the timer ran out and the defaults were played
+func broadcastTurnTimer(session *state.GameSession, expired bool) {
+	ctx := context.Background()
+	if expired {
+		session.BroadcastGame(ctx, func(client *state.Client) []byte {
+			return state.FormatSSE(endpoints.SSEEventGame, renderGameBoard(session, client.SessionID))
+		})
+		return
+	}
+	data := state.FormatSSE(endpoints.SSEEventTimer, components.RenderTurnTimer(session))
+	session.BroadcastGame(ctx, func(client *state.Client) []byte {
+		return data
+	})
+}
+
+// renderGameBoard renders the game board HTML as seen by the session's player
+func renderGameBoard(session *state.GameSession, sessionID string) []byte {
+	return components.RenderGameBoard(session, sessionID)
 }
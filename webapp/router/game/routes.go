@@ -3,11 +3,17 @@ package game
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/ninesl/zombie-chickens/webapp/router/endpoints"
+	"github.com/ninesl/zombie-chickens/webapp/state"
 )
 
 // Routes registers game-related routes
 func Routes(r chi.Router) {
+	// Prompts answered by the turn timer reach everyone like any other answer
+	state.OnTurnTimer = broadcastTurnTimer
+
 	r.Get(endpoints.GamePage, HandleGamePage())
 	r.Get(endpoints.GameConnect, HandleGameConnect())
 	r.Post(endpoints.GameInput, HandleGameInput())
+	r.Post(endpoints.GameNight, HandleGameNight())
+	r.Post(endpoints.GameUndo, HandleGameUndo())
 }
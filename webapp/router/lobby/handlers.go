@@ -3,13 +3,17 @@ package lobby
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ninesl/zombie-chickens/webapp/middleware"
 	"github.com/ninesl/zombie-chickens/webapp/router/endpoints"
 	"github.com/ninesl/zombie-chickens/webapp/state"
 	"github.com/ninesl/zombie-chickens/webapp/ui/pages"
+	"github.com/ninesl/zombie-chickens/zcgame"
 )
 
 // HandleLobbyPage serves the main lobby page
@@ -18,10 +22,11 @@ func HandleLobbyPage() http.HandlerFunc {
 		session := state.GetSession()
 		sessionID := middleware.GetSessionID(r.Context())
 
-		// Check if already in game and game started - redirect to game
+		// Check if already in game and game started - redirect to game,
+		// where eliminated players carry on as spectators
 		if session.IsStarted() {
-			_, playerIdx := session.GetPlayerBySession(sessionID)
-			if playerIdx >= 0 {
+			playerInfo, _ := session.GetPlayerBySession(sessionID)
+			if playerInfo != nil {
 				http.Redirect(w, r, endpoints.GamePage, http.StatusSeeOther)
 				return
 			}
@@ -170,6 +175,163 @@ func HandleLobbyStart() http.HandlerFunc {
 	}
 }
 
+// HandleLobbyOptions handles the first player changing the game options
+func HandleLobbyOptions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session := state.GetSession()
+		sessionID := middleware.GetSessionID(r.Context())
+
+		// Only first player can change options
+		if !session.IsFirstPlayer(sessionID) {
+			http.Error(w, "only first player can change options", http.StatusForbidden)
+			return
+		}
+
+		options := session.Options()
+		options.DefenseExpansion = r.FormValue(endpoints.FieldDefenseExpansion) != ""
+		options.ZombieExpansion = r.FormValue(endpoints.FieldZombieExpansion) != ""
+		options.Roles = r.FormValue(endpoints.FieldRoles) != ""
+		options.Reorganize = r.FormValue(endpoints.FieldReorganize) != ""
+		options.SimultaneousDiscards = r.FormValue(endpoints.FieldSimultaneous) != ""
+		bossEvery, err := strconv.Atoi(r.FormValue(endpoints.FieldBossEvery))
+		if err != nil || bossEvery < 0 {
+			http.Error(w, "invalid boss night interval", http.StatusBadRequest)
+			return
+		}
+		options.BossEvery = bossEvery
+		deckExhaustion, err := zcgame.ParseDeckExhaustionRule(r.FormValue(endpoints.FieldDeckExhaustion))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		options.DeckExhaustion = deckExhaustion
+		maxNights, err := strconv.Atoi(r.FormValue(endpoints.FieldMaxNights))
+		if err != nil || maxNights < 0 {
+			http.Error(w, "invalid max nights", http.StatusBadRequest)
+			return
+		}
+		options.MaxNights = maxNights
+		setup, err := zcgame.ParseSetupVariant(r.FormValue(endpoints.FieldSetup))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		options.Setup = setup
+		rules, err := parseRules(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		options.Rules = rules
+		timerSeconds, err := strconv.Atoi(r.FormValue(endpoints.FieldTurnTimer))
+		if err != nil || timerSeconds < 0 {
+			http.Error(w, "invalid turn timer", http.StatusBadRequest)
+			return
+		}
+		if err := session.SetTurnTimer(time.Duration(timerSeconds) * time.Second); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Empty leaves every player on their browser's language
+		var lang zcgame.Language
+		if value := r.FormValue(endpoints.FieldLanguage); value != "" {
+			if lang, err = zcgame.ParseLanguage(value); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if err := session.SetLanguage(lang); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mode, err := zcgame.ParseGameMode(r.FormValue(endpoints.FieldMode))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		options.Mode = mode
+		// Co-op and solo players have no opponents to bait, sabotage or trade with
+		options.InteractionCards = mode != zcgame.ModeCoop && mode != zcgame.ModeSolo && r.FormValue(endpoints.FieldInteractionCards) != ""
+		options.Teams = nil
+		if mode == zcgame.ModeTeams {
+			// One team per player in seat order; missing players are filled in at start
+			options.Teams = []int{}
+			for _, value := range r.Form[endpoints.FieldTeam] {
+				team, err := strconv.Atoi(value)
+				if err != nil || team < 1 {
+					http.Error(w, "invalid team", http.StatusBadRequest)
+					return
+				}
+				options.Teams = append(options.Teams, team-1)
+			}
+		}
+
+		if err := session.SetOptions(options); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := session.SetDaily(mode == zcgame.ModeSolo && r.FormValue(endpoints.FieldDaily) != ""); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := session.SetTutorial(mode == zcgame.ModeSolo && r.FormValue(endpoints.FieldTutorial) != ""); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		level := 0
+		if mode == zcgame.ModeSolo && r.FormValue(endpoints.FieldLevel) != "" {
+			level, err = strconv.Atoi(r.FormValue(endpoints.FieldLevel))
+			if err != nil || level < 0 || level > len(zcgame.Campaign) {
+				http.Error(w, "invalid campaign level", http.StatusBadRequest)
+				return
+			}
+		}
+		if err := session.SetLevel(level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Broadcast so everyone sees the new options
+		ctx := context.Background()
+		session.BroadcastLobby(ctx, func() []byte {
+			data := renderLobbyContent(session, sessionID, true)
+			return state.FormatSSE(endpoints.SSEEventLobby, data)
+		})
+
+		w.Header().Set("Content-Type", "text/html")
+		pages.LobbyContent(session, sessionID, true).Render(r.Context(), w)
+	}
+}
+
+// parseRules reads the house rules from the lobby options form. The lobby
+// always sends every field, so an empty field is an error rather than a
+// default.
+func parseRules(r *http.Request) (zcgame.Rules, error) {
+	rules := zcgame.Rules{}
+	for _, field := range []struct {
+		name  string
+		value *int
+	}{
+		{endpoints.FieldHandSize, &rules.HandSize},
+		{endpoints.FieldPlays, &rules.PlaysPerTurn},
+		{endpoints.FieldDraws, &rules.DrawsPerTurn},
+		{endpoints.FieldPublicCards, &rules.PublicCards},
+		{endpoints.FieldStartingLives, &rules.StartingLives},
+	} {
+		value, err := strconv.Atoi(r.FormValue(field.name))
+		if err != nil {
+			return zcgame.Rules{}, fmt.Errorf("invalid %s", strings.ReplaceAll(field.name, "_", " "))
+		}
+		*field.value = value
+	}
+	nightCards, err := zcgame.ParseNightCardCurve(r.FormValue(endpoints.FieldNightCards))
+	if err != nil {
+		return zcgame.Rules{}, err
+	}
+	rules.NightCards = nightCards
+	return rules, rules.Validate()
+}
+
 func renderLobbyContent(session *state.GameSession, sessionID string, joined bool) []byte {
 	var buf bytes.Buffer
 	pages.LobbyContent(session, sessionID, joined).Render(context.Background(), &buf)
@@ -11,4 +11,5 @@ func Routes(r chi.Router) {
 	r.Get(endpoints.LobbyConnect, HandleLobbyConnect())
 	r.Post(endpoints.LobbyJoin, HandleLobbyJoin())
 	r.Post(endpoints.LobbyStart, HandleLobbyStart())
+	r.Post(endpoints.LobbyOptions, HandleLobbyOptions())
 }
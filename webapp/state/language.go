@@ -0,0 +1,58 @@
+package state
+
+import "github.com/ninesl/zombie-chickens/zcgame"
+
+// Language
+//
+// Prompts and events are shown in each player's own language: whatever their
+// browser's Accept-Language header prefers most out of the message catalog's
+// languages. The lobby can instead pick one language for everybody, so a table
+// sharing a screen sees the same words.
+
+// Language returns the language the lobby picked for every player, or "" if
+// each player sees their browser's language
+func (gs *GameSession) Language() zcgame.Language {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.language
+}
+
+// SetLanguage picks the language every player sees, or "" to use each
+// player's browser language (lobby only)
+func (gs *GameSession) SetLanguage(lang zcgame.Language) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.started {
+		return ErrGameAlreadyStarted
+	}
+	gs.language = lang
+	return nil
+}
+
+// SetBrowserLanguage records the language the session's browser prefers, from
+// its Accept-Language header
+func (gs *GameSession) SetBrowserLanguage(sessionID string, acceptLanguage string) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.browserLanguages == nil {
+		gs.browserLanguages = map[string]zcgame.Language{}
+	}
+	gs.browserLanguages[sessionID] = zcgame.MatchLanguage(acceptLanguage)
+}
+
+// LanguageFor returns the language the session's player sees: the lobby's
+// pick, or else their browser's, or else English
+func (gs *GameSession) LanguageFor(sessionID string) zcgame.Language {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+
+	if gs.language != "" {
+		return gs.language
+	}
+	if lang, ok := gs.browserLanguages[sessionID]; ok {
+		return lang
+	}
+	return zcgame.English
+}
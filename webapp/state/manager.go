@@ -3,8 +3,9 @@ package state
 import (
 	"bytes"
 	"context"
-	"fmt"
+	"log"
 	"sync"
+	"time"
 
 	"github.com/ninesl/zombie-chickens/zcgame"
 )
@@ -13,7 +14,8 @@ import (
 type PlayerInfo struct {
 	SessionID string
 	Name      string
-	Index     int // -1 until game starts
+	Index     int             // Seat in the lobby
+	ID        zcgame.PlayerID // Player in the game, 0 until it starts
 }
 
 // Client represents a connected SSE client
@@ -30,9 +32,31 @@ type GameSession struct {
 	game         zcgame.GameView
 	pendingInput *zcgame.PlayerInputNeeded
 	players      []PlayerInfo
+	options      zcgame.GameOptions
+	capacity     int // Most players the lobby accepts
 	started      bool
 	gameOver     bool
 
+	// Solo games
+	daily           bool                      // Play today's daily challenge instead of options
+	dailyDate       string                    // DailyDate of the daily challenge being played
+	leaderboardPath string                    // File solo scores are saved to, empty to not save
+	leaderboard     []zcgame.LeaderboardEntry // Top scores, once a solo game is over
+
+	// Campaign
+	level        int    // 1-based campaign level to play, 0 for a free game
+	tutorial     bool   // Play the guided tutorial game instead of options or a level
+	progressPath string // File campaign progress is saved to, empty to not save
+
+	// Turn timer
+	turnTimer time.Duration // How long each prompt waits before its default is submitted, 0 for no limit
+	deadline  time.Time     // When the waiting prompts time out, zero if they aren't timed
+	timerGen  int           // Counts restarts, so a replaced timer knows to stop
+
+	// Language
+	language         zcgame.Language            // Language every player sees, "" for each browser's own
+	browserLanguages map[string]zcgame.Language // Language each session's browser prefers
+
 	// SSE clients
 	lobbyClients []*Client
 	gameClients  []*Client
@@ -48,7 +72,8 @@ var (
 func GetSession() *GameSession {
 	sessionOnce.Do(func() {
 		session = &GameSession{
-			players:      make([]PlayerInfo, 0, 4),
+			players:      make([]PlayerInfo, 0, zcgame.MaxPlayers),
+			capacity:     zcgame.MaxPlayers,
 			lobbyClients: make([]*Client, 0),
 			gameClients:  make([]*Client, 0),
 		}
@@ -56,12 +81,24 @@ func GetSession() *GameSession {
 	return session
 }
 
-// ResetSession resets the global session (for testing/new game)
+// ResetSession resets the global session (for testing/new game).
+// The lobby capacity, leaderboard file and campaign progress file carry over
+// to the new session.
 func ResetSession() {
+	capacity := zcgame.MaxPlayers
+	leaderboardPath, progressPath := "", ""
+	if session != nil {
+		capacity = session.capacity
+		leaderboardPath = session.leaderboardPath
+		progressPath = session.progressPath
+	}
 	session = &GameSession{
-		players:      make([]PlayerInfo, 0, 4),
-		lobbyClients: make([]*Client, 0),
-		gameClients:  make([]*Client, 0),
+		players:         make([]PlayerInfo, 0, capacity),
+		capacity:        capacity,
+		leaderboardPath: leaderboardPath,
+		progressPath:    progressPath,
+		lobbyClients:    make([]*Client, 0),
+		gameClients:     make([]*Client, 0),
 	}
 }
 
@@ -74,7 +111,7 @@ func (gs *GameSession) AddPlayer(sessionID, name string) (int, error) {
 		return -1, ErrGameAlreadyStarted
 	}
 
-	if len(gs.players) >= 4 {
+	if len(gs.players) >= gs.capacity {
 		return -1, ErrGameFull
 	}
 
@@ -85,49 +122,18 @@ func (gs *GameSession) AddPlayer(sessionID, name string) (int, error) {
 		}
 	}
 
-	// Make name unique by appending a number if needed
-	uniqueName := gs.makeUniqueName(name)
-
 	idx := len(gs.players)
 	gs.players = append(gs.players, PlayerInfo{
 		SessionID: sessionID,
-		Name:      uniqueName,
+		Name:      name,
 		Index:     idx,
 	})
 
 	return idx, nil
 }
 
-// makeUniqueName ensures the name is unique among current players
-func (gs *GameSession) makeUniqueName(name string) string {
-	// Check if name already exists
-	exists := func(n string) bool {
-		for _, p := range gs.players {
-			if p.Name == n {
-				return true
-			}
-		}
-		return false
-	}
-
-	if !exists(name) {
-		return name
-	}
-
-	// Append numbers until unique
-	for i := 2; i <= 10; i++ {
-		candidate := fmt.Sprintf("%s%d", name, i)
-		if !exists(candidate) {
-			return candidate
-		}
-	}
-
-	// Fallback: use session ID prefix
-	return fmt.Sprintf("%s_%s", name, gs.players[len(gs.players)].SessionID[:4])
-}
-
 // GetPlayerBySession returns player info and their current game index by session ID.
-// The index is looked up dynamically from the game state by matching the player's name,
+// The index is looked up dynamically from the game state by the player's ID,
 // so it remains correct even after other players are eliminated and indices shift.
 // Returns (nil, -1) if session not found, or (playerInfo, -1) if player was eliminated.
 func (gs *GameSession) GetPlayerBySession(sessionID string) (*PlayerInfo, int) {
@@ -151,9 +157,8 @@ func (gs *GameSession) GetPlayerBySession(sessionID string) (*PlayerInfo, int) {
 		return playerInfo, playerInfo.Index
 	}
 
-	// Look up current index by name in the game state
-	idx := gs.game.PlayerIdxByName(playerInfo.Name)
-	return playerInfo, idx
+	// Look up current index by ID in the game state
+	return playerInfo, gs.game.PlayerIdxByID(playerInfo.ID)
 }
 
 // Players returns a copy of the player list
@@ -187,6 +192,30 @@ func (gs *GameSession) IsGameOver() bool {
 	return gs.gameOver
 }
 
+// Capacity returns the most players the lobby accepts
+func (gs *GameSession) Capacity() int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.capacity
+}
+
+// SetCapacity changes the most players the lobby accepts (1 to zcgame.MaxPlayers).
+// It cannot drop below the number of players already in the lobby.
+func (gs *GameSession) SetCapacity(capacity int) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.started {
+		return ErrGameAlreadyStarted
+	}
+	if capacity < 1 || capacity > zcgame.MaxPlayers || capacity < len(gs.players) {
+		return ErrInvalidCapacity
+	}
+
+	gs.capacity = capacity
+	return nil
+}
+
 // IsFirstPlayer checks if sessionID is the first player (can start game)
 func (gs *GameSession) IsFirstPlayer(sessionID string) bool {
 	gs.mu.RLock()
@@ -198,6 +227,141 @@ func (gs *GameSession) IsFirstPlayer(sessionID string) bool {
 	return gs.players[0].SessionID == sessionID
 }
 
+// Options returns the game options that will be used when the game starts
+func (gs *GameSession) Options() zcgame.GameOptions {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.options
+}
+
+// SetOptions changes the game options (lobby only)
+func (gs *GameSession) SetOptions(options zcgame.GameOptions) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.started {
+		return ErrGameAlreadyStarted
+	}
+
+	gs.options = options
+	return nil
+}
+
+// IsDaily returns true if the lobby is set to play today's daily challenge
+func (gs *GameSession) IsDaily() bool {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.daily
+}
+
+// SetDaily chooses whether to play today's daily challenge (lobby only).
+// The daily challenge replaces the options when the game starts.
+func (gs *GameSession) SetDaily(daily bool) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.started {
+		return ErrGameAlreadyStarted
+	}
+
+	gs.daily = daily
+	return nil
+}
+
+// SetLeaderboardPath sets the file solo scores are saved to
+func (gs *GameSession) SetLeaderboardPath(path string) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.leaderboardPath = path
+}
+
+// Leaderboard returns the top solo scores once a solo game is over
+func (gs *GameSession) Leaderboard() []zcgame.LeaderboardEntry {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.leaderboard
+}
+
+// DailyDate returns the date of the daily challenge being played, or empty
+func (gs *GameSession) DailyDate() string {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.dailyDate
+}
+
+// Level returns the 1-based campaign level the lobby is set to play, or 0
+func (gs *GameSession) Level() int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.level
+}
+
+// SetLevel chooses the 1-based campaign level to play, or 0 for a free game
+// (lobby only). Locked levels can't be chosen. The level replaces the options
+// when the game starts.
+func (gs *GameSession) SetLevel(level int) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.started {
+		return ErrGameAlreadyStarted
+	}
+	if level != 0 && !gs.campaignProgressLocked().Unlocked(level-1) {
+		return ErrLevelLocked
+	}
+
+	gs.level = level
+	return nil
+}
+
+// IsTutorial returns true if the lobby is set to play the tutorial game
+func (gs *GameSession) IsTutorial() bool {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.tutorial
+}
+
+// SetTutorial chooses whether to play the guided tutorial game (lobby only).
+// The tutorial replaces the options and any campaign level when the game starts.
+func (gs *GameSession) SetTutorial(tutorial bool) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.started {
+		return ErrGameAlreadyStarted
+	}
+
+	gs.tutorial = tutorial
+	return nil
+}
+
+// SetProgressPath sets the file campaign progress is saved to
+func (gs *GameSession) SetProgressPath(path string) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.progressPath = path
+}
+
+// CampaignProgress returns the saved campaign progress
+func (gs *GameSession) CampaignProgress() *zcgame.CampaignProgress {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.campaignProgressLocked()
+}
+
+// campaignProgressLocked loads the campaign progress, or a fresh campaign if
+// it can't be read (must hold lock)
+func (gs *GameSession) campaignProgressLocked() *zcgame.CampaignProgress {
+	if gs.progressPath != "" {
+		progress, err := zcgame.LoadCampaignProgress(gs.progressPath)
+		if err == nil {
+			return progress
+		}
+		log.Printf("could not load campaign progress: %v", err)
+	}
+	return &zcgame.CampaignProgress{Completed: map[string]time.Time{}}
+}
+
 // StartGame starts the game with current players
 func (gs *GameSession) StartGame() error {
 	gs.mu.Lock()
@@ -216,12 +380,36 @@ func (gs *GameSession) StartGame() error {
 	for i, p := range gs.players {
 		names[i] = p.Name
 	}
+	if gs.options.Mode == zcgame.ModeTeams {
+		gs.options.Teams = TeamsForPlayers(gs.options.Teams, len(gs.players))
+	}
+	if gs.daily && gs.level == 0 && !gs.tutorial {
+		now := time.Now()
+		gs.options = zcgame.DailyChallenge(now)
+		gs.dailyDate = zcgame.DailyDate(now)
+	}
 
 	// Create the game
-	game, err := zcgame.CreateNewGame(names...)
+	var game zcgame.GameView
+	var err error
+	if (gs.level > 0 || gs.tutorial) && len(names) != 1 {
+		return ErrLevelNeedsOnePlayer
+	}
+	if gs.tutorial {
+		game, err = zcgame.CreateTutorialGame(names[0])
+	} else if gs.level > 0 {
+		game, err = zcgame.CreateLevelGame(zcgame.Campaign[gs.level-1], names[0])
+	} else {
+		game, err = zcgame.CreateNewGameWithOptions(gs.options, names...)
+	}
 	if err != nil {
 		return err
 	}
+	gs.options = game.Options()
+	// Players are created in seat order
+	for i := range gs.players {
+		gs.players[i].ID = game.Player(i).ID()
+	}
 
 	gs.game = game
 	gs.started = true
@@ -229,7 +417,67 @@ func (gs *GameSession) StartGame() error {
 	// Start the game - get first input needed
 	_, inputNeeded := gs.game.ContinueDay()
 	gs.pendingInput = inputNeeded
+	gs.restartTimerLocked()
+
+	return nil
+}
+
+// TeamsForPlayers fits the lobby's team choices to the number of players.
+// Players who joined after the teams were chosen alternate between the first two teams.
+func TeamsForPlayers(teams []int, numPlayers int) []int {
+	result := make([]int, numPlayers)
+	for i := range result {
+		if i < len(teams) {
+			result[i] = teams[i]
+		} else {
+			result[i] = i % 2
+		}
+	}
+	return result
+}
+
+// ViewerIdx returns the game index of the session's player, or -1 if they
+// aren't playing. Game indices shift as players are eliminated.
+func (gs *GameSession) ViewerIdx(sessionID string) int {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	playerInfo, _ := gs.getPlayerBySessionLocked(sessionID)
+	if playerInfo == nil || !gs.started {
+		return -1
+	}
+	return gs.game.PlayerIdxByID(playerInfo.ID)
+}
+
+// Spectator returns the session's player once they have been eliminated, as
+// they were when they went out. The bool is false while they are still playing
+// or if they never joined.
+func (gs *GameSession) Spectator(sessionID string) (zcgame.PlayerView, bool) {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	playerInfo, _ := gs.getPlayerBySessionLocked(sessionID)
+	if playerInfo == nil || !gs.started || gs.game.PlayerIdxByID(playerInfo.ID) != -1 {
+		return zcgame.PlayerView{}, false
+	}
+	return gs.game.PlayerByID(playerInfo.ID)
+}
 
+// SetNightChoices sets how the session's player answers night prompts.
+func (gs *GameSession) SetNightChoices(sessionID string, choices zcgame.PlayerNightChoices) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if !gs.started {
+		return ErrGameNotStarted
+	}
+	playerInfo, _ := gs.getPlayerBySessionLocked(sessionID)
+	if playerInfo == nil {
+		return ErrPlayerNotFound
+	}
+	idx := gs.game.PlayerIdxByID(playerInfo.ID)
+	if idx == -1 {
+		return ErrPlayerNotFound
+	}
+	gs.game.SetNightChoices(idx, choices)
 	return nil
 }
 
@@ -247,41 +495,84 @@ func (gs *GameSession) PendingInput() *zcgame.PlayerInputNeeded {
 	return gs.pendingInput
 }
 
+// PendingInputs returns every prompt waiting to be answered, keyed by player index
+func (gs *GameSession) PendingInputs() map[int]*zcgame.PlayerInputNeeded {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	if gs.pendingInput == nil {
+		return nil
+	}
+	return gs.game.PendingInputs()
+}
+
 // SubmitInput processes player input and advances game state
 func (gs *GameSession) SubmitInput(sessionID string, choice int) error {
 	gs.mu.Lock()
 	defer gs.mu.Unlock()
 
+	playerIdx, input, err := gs.checkInputLocked(sessionID)
+	if err != nil {
+		return err
+	}
+	if !isValidChoice(choice, input.ValidChoices) {
+		return ErrInvalidChoice
+	}
+
+	// Process input
+	gs.advanceLocked(gs.game.ContinueAfterInputFrom(playerIdx, choice))
+	return nil
+}
+
+// SubmitSelection answers a multi-select prompt with all of its choices at once
+func (gs *GameSession) SubmitSelection(sessionID string, choices []int) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	playerIdx, input, err := gs.checkInputLocked(sessionID)
+	if err != nil {
+		return err
+	}
+	if !input.ValidSelection(choices) {
+		return ErrInvalidChoice
+	}
+
+	gs.advanceLocked(gs.game.ContinueAfterInputsFrom(playerIdx, choices))
+	return nil
+}
+
+// checkInputLocked returns the session player's index and the prompt waiting
+// on them, or an error if nothing is (must hold lock)
+func (gs *GameSession) checkInputLocked(sessionID string) (int, *zcgame.PlayerInputNeeded, error) {
 	if !gs.started {
-		return ErrGameNotStarted
+		return 0, nil, ErrGameNotStarted
 	}
 
 	if gs.gameOver {
-		return ErrGameOver
+		return 0, nil, ErrGameOver
 	}
 
-	// Validate it's this player's turn to provide input
-	// Use ActiveInputPlayerIdx which handles event discards where a different player
-	// than CurrentPlayerIdx needs to provide input
-	activeIdx := gs.game.ActiveInputPlayerIdx()
-	playerInfo, playerIdx := gs.getPlayerBySessionLocked(sessionID)
+	playerInfo, _ := gs.getPlayerBySessionLocked(sessionID)
 	if playerInfo == nil {
-		return ErrPlayerNotFound
+		return 0, nil, ErrPlayerNotFound
 	}
-	if playerIdx != activeIdx {
-		return ErrNotYourTurn
-	}
-
-	// Validate choice
+	playerIdx := gs.game.PlayerIdxByID(playerInfo.ID)
 	if gs.pendingInput == nil {
-		return ErrNoInputNeeded
+		return 0, nil, ErrNoInputNeeded
 	}
-	if !isValidChoice(choice, gs.pendingInput.ValidChoices) {
-		return ErrInvalidChoice
+
+	// Validate it's this player's turn to provide input. Usually only the
+	// ActiveInputPlayerIdx is waiting, but during simultaneous event discards
+	// every player with cards left to discard is
+	input, ok := gs.game.PendingInputs()[playerIdx]
+	if !ok {
+		return 0, nil, ErrNotYourTurn
 	}
+	return playerIdx, input, nil
+}
 
-	// Process input
-	gameContinues, inputNeeded := gs.game.ContinueAfterInput(choice)
+// advanceLocked records the game's response to input, moving it on until it
+// needs input again or is over (must hold lock)
+func (gs *GameSession) advanceLocked(gameContinues bool, inputNeeded *zcgame.PlayerInputNeeded) {
 	gs.pendingInput = inputNeeded
 
 	// If no input needed but game continues, advance to next phase
@@ -295,11 +586,67 @@ func (gs *GameSession) SubmitInput(sessionID string, choice int) error {
 	// (false, nil) = game over
 	if !gameContinues && inputNeeded == nil {
 		gs.gameOver = true
+		switch {
+		case gs.tutorial:
+			// Tutorial games are not scored
+		case gs.level > 0:
+			gs.recordLevelLocked()
+		default:
+			gs.recordSoloGameLocked()
+		}
 	}
+	gs.restartTimerLocked()
+}
+
+// Undo takes back the session player's last decision of their day turn
+func (gs *GameSession) Undo(sessionID string) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
 
+	if !gs.started {
+		return ErrGameNotStarted
+	}
+	if gs.gameOver {
+		return ErrGameOver
+	}
+	playerInfo, _ := gs.getPlayerBySessionLocked(sessionID)
+	if playerInfo == nil {
+		return ErrPlayerNotFound
+	}
+	if gs.game.PlayerIdxByID(playerInfo.ID) != gs.game.ActiveInputPlayerIdx() {
+		return ErrNotYourTurn
+	}
+	if !gs.game.CanUndo() {
+		return ErrNothingToUndo
+	}
+	gs.pendingInput = gs.game.Undo()
+	gs.restartTimerLocked()
 	return nil
 }
 
+// recordSoloGameLocked saves a finished solo game to the leaderboard (must hold lock)
+func (gs *GameSession) recordSoloGameLocked() {
+	if gs.options.Mode != zcgame.ModeSolo || gs.leaderboardPath == "" {
+		return
+	}
+	lb, err := zcgame.RecordSoloGame(gs.leaderboardPath, gs.game, gs.players[0].Name, gs.dailyDate)
+	if err != nil {
+		log.Printf("could not save the leaderboard: %v", err)
+		return
+	}
+	gs.leaderboard = lb.Top(10, gs.dailyDate)
+}
+
+// recordLevelLocked saves a won campaign level to the progress file (must hold lock)
+func (gs *GameSession) recordLevelLocked() {
+	if gs.progressPath == "" {
+		return
+	}
+	if _, err := zcgame.RecordLevelResult(gs.progressPath, gs.game); err != nil {
+		log.Printf("could not save campaign progress: %v", err)
+	}
+}
+
 // getPlayerBySessionLocked returns player info (must hold lock)
 func (gs *GameSession) getPlayerBySessionLocked(sessionID string) (*PlayerInfo, int) {
 	for i, p := range gs.players {
@@ -413,15 +760,16 @@ func (gs *GameSession) BroadcastLobby(ctx context.Context, render func() []byte)
 	}
 }
 
-// BroadcastGame sends game state update to all connected game clients
-func (gs *GameSession) BroadcastGame(ctx context.Context, render func() []byte) {
+// BroadcastGame sends game state update to all connected game clients.
+// Each client gets its own render, since team games hide opponents' hands.
+func (gs *GameSession) BroadcastGame(ctx context.Context, render func(client *Client) []byte) {
 	gs.mu.RLock()
 	clients := make([]*Client, len(gs.gameClients))
 	copy(clients, gs.gameClients)
 	gs.mu.RUnlock()
 
-	data := render()
 	for _, client := range clients {
+		data := render(client)
 		select {
 		case client.Send <- data:
 		case <-client.Done:
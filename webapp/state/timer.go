@@ -0,0 +1,120 @@
+package state
+
+import (
+	"log"
+	"time"
+)
+
+// Turn Timer
+//
+// A player who walks away would stall everyone else, so the lobby can give
+// every prompt a time limit. When it runs out, the session answers each waiting
+// prompt with its DefaultChoice as if the player had. The timer restarts
+// whenever the game asks for new input, and OnTurnTimer is called every second
+// so the time left can be shown.
+
+// TurnTimerTick is how often OnTurnTimer is called while a prompt is timed.
+const TurnTimerTick = time.Second
+
+// OnTurnTimer is called every TurnTimerTick while a prompt is timed, with
+// expired set once the time ran out and the defaults were submitted. It is
+// called without the session lock held. The game routes set it to broadcast
+// the timer to every client.
+var OnTurnTimer func(gs *GameSession, expired bool)
+
+// TurnTimer returns how long each prompt waits for an answer, 0 for no limit
+func (gs *GameSession) TurnTimer() time.Duration {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gs.turnTimer
+}
+
+// SetTurnTimer changes how long each prompt waits for an answer, 0 for no
+// limit (lobby only)
+func (gs *GameSession) SetTurnTimer(d time.Duration) error {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gs.started {
+		return ErrGameAlreadyStarted
+	}
+	if d < 0 {
+		return ErrInvalidTurnTimer
+	}
+	gs.turnTimer = d
+	return nil
+}
+
+// TimeLeft returns how long the waiting prompts have left, and false if they
+// aren't timed
+func (gs *GameSession) TimeLeft() (time.Duration, bool) {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	if gs.deadline.IsZero() {
+		return 0, false
+	}
+	return max(time.Until(gs.deadline), 0), true
+}
+
+// restartTimerLocked times the prompts now waiting, replacing any earlier
+// timer. Called whenever the game asks for new input (must hold lock).
+func (gs *GameSession) restartTimerLocked() {
+	gs.timerGen++
+	gs.deadline = time.Time{}
+	if gs.turnTimer <= 0 || !gs.started || gs.gameOver || gs.pendingInput == nil {
+		return
+	}
+	gs.deadline = time.Now().Add(gs.turnTimer)
+	go gs.runTimer(gs.timerGen, gs.deadline)
+}
+
+// runTimer reports the time left every TurnTimerTick, then submits the
+// defaults at the deadline. It gives up as soon as a newer timer is started.
+func (gs *GameSession) runTimer(gen int, deadline time.Time) {
+	ticker := time.NewTicker(TurnTimerTick)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if time.Now().Before(deadline) {
+			if !gs.timerCurrent(gen) {
+				return
+			}
+			if OnTurnTimer != nil {
+				OnTurnTimer(gs, false)
+			}
+			continue
+		}
+		if gs.expireTimer(gen) && OnTurnTimer != nil {
+			OnTurnTimer(gs, true)
+		}
+		return
+	}
+}
+
+// timerCurrent returns true if gen is still the running timer
+func (gs *GameSession) timerCurrent(gen int) bool {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return gen == gs.timerGen
+}
+
+// expireTimer answers every prompt that was waiting when the timer ran out
+// with its default. Returns false if the timer had already been replaced.
+func (gs *GameSession) expireTimer(gen int) bool {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if gen != gs.timerGen || gs.pendingInput == nil || gs.gameOver {
+		return false
+	}
+	for playerIdx := range gs.game.PendingInputs() {
+		// Answering one player's prompt can move the game on past the others'
+		input, ok := gs.game.PendingInputs()[playerIdx]
+		if !ok || gs.gameOver {
+			continue
+		}
+		log.Printf("[TIMER] Player %d timed out, submitting default %d", playerIdx, input.DefaultChoice)
+		gs.advanceLocked(gs.game.ContinueAfterInputFrom(playerIdx, input.DefaultChoice))
+	}
+	return true
+}
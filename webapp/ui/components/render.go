@@ -5,14 +5,34 @@ import (
 	"context"
 
 	"github.com/ninesl/zombie-chickens/webapp/state"
+	"github.com/ninesl/zombie-chickens/zcgame"
 )
 
-// RenderGameBoard renders the game board to bytes
-func RenderGameBoard(session *state.GameSession) []byte {
+// RenderGameBoard renders the game board to bytes, as seen by the session's player
+func RenderGameBoard(session *state.GameSession, sessionID string) []byte {
+	var spectator *zcgame.PlayerView
+	if pv, ok := session.Spectator(sessionID); ok {
+		spectator = &pv
+	}
+	// Prompts are shown in the viewer's language
+	lang := session.LanguageFor(sessionID)
+	pendingInputs := map[int]*zcgame.PlayerInputNeeded{}
+	for playerIdx, input := range session.PendingInputs() {
+		pendingInputs[playerIdx] = input.Localized(lang)
+	}
+	timeLeft, timed := session.TimeLeft()
 	props := BoardProps{
-		Game:         session.Game(),
-		PendingInput: session.PendingInput(),
-		GameOver:     session.IsGameOver(),
+		Game:          session.Game(),
+		PendingInput:  session.PendingInput().Localized(lang),
+		PendingInputs: pendingInputs,
+		GameOver:      session.IsGameOver(),
+		ViewerIdx:     session.ViewerIdx(sessionID),
+		Leaderboard:   session.Leaderboard(),
+		DailyDate:     session.DailyDate(),
+		Spectator:     spectator,
+		TimeLeft:      timeLeft,
+		Timed:         timed,
+		Lang:          lang,
 	}
 
 	var buf bytes.Buffer
@@ -20,6 +40,14 @@ func RenderGameBoard(session *state.GameSession) []byte {
 	return buf.Bytes()
 }
 
+// RenderTurnTimer renders the time left on the waiting prompts to bytes
+func RenderTurnTimer(session *state.GameSession) []byte {
+	timeLeft, timed := session.TimeLeft()
+	var buf bytes.Buffer
+	TurnTimer(timeLeft, timed).Render(context.Background(), &buf)
+	return buf.Bytes()
+}
+
 // RenderLobbyContent renders the lobby content to bytes
 func RenderLobbyContent(session *state.GameSession, sessionID string, joined bool) []byte {
 	var buf bytes.Buffer
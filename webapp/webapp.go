@@ -8,6 +8,8 @@ import (
 	"github.com/ninesl/zombie-chickens/webapp/cmd"
 	"github.com/ninesl/zombie-chickens/webapp/config"
 	"github.com/ninesl/zombie-chickens/webapp/router"
+	"github.com/ninesl/zombie-chickens/webapp/state"
+	"github.com/ninesl/zombie-chickens/zcgame"
 )
 
 // RunServer starts the web server for Zombie Chickens
@@ -17,6 +19,11 @@ func RunServer() {
 
 	// Create config
 	c := config.NewConfig()
+	if err := state.GetSession().SetCapacity(c.Lobby.MaxPlayers); err != nil {
+		log.Fatalf("LOBBY_MAX_PLAYERS must be between 1 and %d: %v", zcgame.MaxPlayers, err)
+	}
+	state.GetSession().SetLeaderboardPath(c.Lobby.LeaderboardPath)
+	state.GetSession().SetProgressPath(c.Lobby.ProgressPath)
 
 	// Set middlewares
 	r.Use(middleware.Logger)
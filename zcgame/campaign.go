@@ -0,0 +1,346 @@
+package zcgame
+
+// Campaign
+//
+// The campaign is a run of solo levels played in order. Each level is plain
+// data: the rules in effect, a starting farm and hand, a scripted or weighted
+// night deck, and a goal such as "survive 4 nights with 2 lives" or "kill 3
+// Boomers". CreateLevelGame deals a solo game and then sets it up as the level
+// describes, so a level plays like any other game in both frontends.
+//
+// A level is won at the end of the night its goal is met. It is lost when the
+// farm falls, when the lives kept by the goal can no longer be kept, or after
+// the level's MaxNights. Finishing a level unlocks the next one; progress is
+// kept in a local file, like the solo leaderboard.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Level is one scripted campaign level.
+type Level struct {
+	ID      string      // Stable key the level's progress is saved under
+	Name    string      // Display name
+	Intro   string      // Shown before the level starts; says what the level teaches
+	Options GameOptions // Rules in effect; Mode is always solo, and Roles and Setup are ignored
+
+	Lives int            // Starting lives (0 keeps the solo default)
+	Farm  Stacks         // Starting farm; its cards are taken out of the day deck
+	Hand  []FarmItemType // Starting hand, at most Rules.HandSize cards; empty slots are dealt from the deck
+	Draws []FarmItemType // Day cards put on top of the day deck in order; the first two are dealt face up
+
+	// Night cards by zombie or event name. Script is drawn first, in order:
+	// the first night deals 1 card, the second 2, and so on. Weights, when set,
+	// replace the rest of the night deck with that many copies of each card.
+	Script  []string
+	Weights map[string]int
+
+	Goal LevelGoal
+}
+
+// LevelGoal is what a level asks of the player. Every non-zero part must be
+// met at the end of the same night.
+type LevelGoal struct {
+	Nights int    // Nights to survive
+	Lives  int    // Lives to still have
+	Kills  int    // Zombies to kill
+	Zombie string // Name of the zombie to kill; empty counts any zombie
+}
+
+// LevelProgress is how far a level's goal has been met.
+type LevelProgress struct {
+	Nights int // Nights survived
+	Lives  int // Lives left
+	Kills  int // Zombies killed that count towards the goal
+}
+
+// CreateLevelGame sets up a solo game for the given level and player name.
+// Returns an error if the level names unknown cards, asks for cards the day
+// deck doesn't have, or builds an illegal farm.
+func CreateLevelGame(level Level, name string) (GameView, error) {
+	options := level.Options
+	options.Mode = ModeSolo
+	options.Roles = false
+	options.Setup = SetupDeal
+	view, err := CreateNewGameWithOptions(options, name)
+	if err != nil {
+		return GameView{}, err
+	}
+	g := view.game
+	g.Level = &level
+	g.LevelKills = map[int]int{}
+	if err := g.setupLevel(); err != nil {
+		return GameView{}, fmt.Errorf("level %s: %w", level.ID, err)
+	}
+	return view, nil
+}
+
+// setupLevel puts the level's lives, farm, hand and night deck in place of the
+// ones dealt by CreateNewGameWithOptions.
+func (g *gameState) setupLevel() error {
+	level, player := g.Level, g.Players[0]
+	if level.Goal.Zombie != "" {
+		if _, ok := zombieKeyByName(level.Goal.Zombie); !ok {
+			return fmt.Errorf("goal: unknown zombie %q", level.Goal.Zombie)
+		}
+	}
+	if level.Lives > 0 {
+		player.Lives = level.Lives
+	}
+
+	// Cards for the farm and hand come out of the deck, so put the dealt hand back
+	if len(level.Hand) > len(player.Hand) {
+		return fmt.Errorf("hand: at most %d cards, got %d", len(player.Hand), len(level.Hand))
+	}
+	for _, item := range player.Hand {
+		g.DayDeck = append(g.DayDeck, item.FarmItemType)
+	}
+	farm := Stacks{}
+	for _, stack := range level.Farm {
+		for _, item := range stack {
+			if !g.takeFromDayDeck(item) {
+				return fmt.Errorf("farm: no %s left in the day deck", item)
+			}
+		}
+		farm = append(farm, append(Stack{}, stack...))
+	}
+	player.Farm.Stacks = farm
+	if err := player.Farm.assertLegalStacks(); err != nil {
+		return fmt.Errorf("farm: %w", err)
+	}
+	for _, item := range level.Hand {
+		if !g.takeFromDayDeck(item) {
+			return fmt.Errorf("hand: no %s left in the day deck", item)
+		}
+	}
+	for _, item := range g.PublicDayCards {
+		if item != NUM_FARM_ITEMS {
+			g.DayDeck = append(g.DayDeck, item)
+		}
+	}
+	for _, item := range level.Draws {
+		if !g.takeFromDayDeck(item) {
+			return fmt.Errorf("draws: no %s left in the day deck", item)
+		}
+	}
+	shuffle(g.rng, g.DayDeck)
+	for i := range player.Hand {
+		if i < len(level.Hand) {
+			player.Hand[i] = HandItem{FarmItemType: level.Hand[i]}
+		} else {
+			player.Hand[i] = HandItem{FarmItemType: g.nextDayCard()}
+		}
+	}
+	g.DayDeck = append(append(Stack{}, level.Draws...), g.DayDeck...)
+	g.dealPublicDayCards()
+
+	if level.Weights != nil {
+		deck := NightCards{}
+		// Sorted names keep the deck the same for a given seed
+		for _, name := range slices.Sorted(maps.Keys(level.Weights)) {
+			card, err := nightCardByName(name)
+			if err != nil {
+				return fmt.Errorf("weights: %w", err)
+			}
+			for range level.Weights[name] {
+				deck = append(deck, card)
+			}
+		}
+		g.NightDeck = shuffle(g.rng, deck)
+		g.FullNightDeck = append(NightCards{}, deck...)
+	}
+	script := NightCards{}
+	for _, name := range level.Script {
+		card, err := nightCardByName(name)
+		if err != nil {
+			return fmt.Errorf("script: %w", err)
+		}
+		script = append(script, card)
+	}
+	g.NightDeck = append(script, g.NightDeck...)
+	if len(g.NightDeck) == 0 {
+		return fmt.Errorf("night deck: is empty")
+	}
+	return nil
+}
+
+// zombieKeyByName returns the ZombieChickens key of the named zombie.
+func zombieKeyByName(name string) (int, bool) {
+	for key, zombie := range ZombieChickens {
+		if strings.EqualFold(zombie.Name, name) {
+			return key, true
+		}
+	}
+	return 0, false
+}
+
+// nightCardByName returns a night card for the named zombie or event.
+// Bosses are dealt by BossEvery, so they can't be put in the night deck.
+func nightCardByName(name string) (NightCard, error) {
+	if key, ok := zombieKeyByName(name); ok {
+		if ZombieChickens[key].IsBoss() {
+			return NightCard{}, fmt.Errorf("%s is a boss; use Options.BossEvery", name)
+		}
+		return NightCard{ZombieKey: key}, nil
+	}
+	for _, event := range NightCardEvents {
+		if strings.EqualFold(event.Name, name) {
+			return NightCard{Event: event, ZombieKey: -1}, nil
+		}
+	}
+	return NightCard{}, fmt.Errorf("unknown night card %q", name)
+}
+
+// isLevel returns true if the game is a campaign level.
+func (g *gameState) isLevel() bool {
+	return g.Level != nil
+}
+
+// recordKill counts a defeated zombie towards the level goal.
+func (g *gameState) recordKill(zombieKey int) {
+	if g.isLevel() {
+		g.LevelKills[zombieKey]++
+	}
+}
+
+// levelProgress returns how far the level goal has been met.
+func (g *gameState) levelProgress() LevelProgress {
+	progress := LevelProgress{Nights: g.NightsSurvived}
+	if len(g.Players) == 1 {
+		progress.Lives = g.Players[0].Lives
+	}
+	if key, ok := zombieKeyByName(g.Level.Goal.Zombie); ok {
+		progress.Kills = g.LevelKills[key]
+	} else {
+		for _, kills := range g.LevelKills {
+			progress.Kills += kills
+		}
+	}
+	return progress
+}
+
+// checkLevelGoal ends a level at the end of a night if its goal was met, or if
+// the player has fewer lives than the goal asks them to keep.
+func (g *gameState) checkLevelGoal() {
+	if !g.isLevel() || len(g.Players) == 0 {
+		return
+	}
+	goal, progress := g.Level.Goal, g.levelProgress()
+	switch {
+	case progress.Lives < goal.Lives:
+		g.endGame(GameEndLevelFailed)
+	case progress.Nights >= goal.Nights && progress.Kills >= goal.Kills:
+		g.endGame(GameEndLevelComplete)
+	}
+}
+
+func (goal LevelGoal) String() string {
+	parts := []string{}
+	if goal.Kills > 0 {
+		zombies := "zombies"
+		if goal.Zombie != "" {
+			zombies = goal.Zombie + "s"
+		}
+		parts = append(parts, fmt.Sprintf("kill %d %s", goal.Kills, zombies))
+	}
+	if goal.Nights > 0 {
+		parts = append(parts, fmt.Sprintf("survive %d %s", goal.Nights, plural(goal.Nights, "night", "nights")))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "survive the night")
+	}
+	result := strings.Join(parts, " and ")
+	if goal.Lives > 0 {
+		result += fmt.Sprintf(" with %d %s", goal.Lives, plural(goal.Lives, "life", "lives"))
+	}
+	return strings.ToUpper(result[:1]) + result[1:]
+}
+
+// plural returns one if n is 1, otherwise many.
+func plural(n int, one string, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// CampaignProgress records which campaign levels have been completed.
+type CampaignProgress struct {
+	Completed map[string]time.Time `json:"completed"` // When each level was first completed, by Level.ID
+}
+
+// LoadCampaignProgress reads a progress file. A missing file is a fresh campaign.
+func LoadCampaignProgress(path string) (*CampaignProgress, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &CampaignProgress{Completed: map[string]time.Time{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var progress CampaignProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, err
+	}
+	if progress.Completed == nil {
+		progress.Completed = map[string]time.Time{}
+	}
+	return &progress, nil
+}
+
+// Save writes the progress to a file.
+func (p *CampaignProgress) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// IsComplete returns true if the level with the given ID has been completed.
+func (p *CampaignProgress) IsComplete(id string) bool {
+	_, ok := p.Completed[id]
+	return ok
+}
+
+// Unlocked returns true if Campaign[idx] can be played: the first level is
+// always open, and each level after it opens once the one before is complete.
+func (p *CampaignProgress) Unlocked(idx int) bool {
+	if idx < 0 || idx >= len(Campaign) {
+		return false
+	}
+	return idx == 0 || p.IsComplete(Campaign[idx-1].ID)
+}
+
+// NextLevel returns the index of the first incomplete level in Campaign, or
+// len(Campaign) once the campaign is finished.
+func (p *CampaignProgress) NextLevel() int {
+	for i, level := range Campaign {
+		if !p.IsComplete(level.ID) {
+			return i
+		}
+	}
+	return len(Campaign)
+}
+
+// RecordLevelResult marks a finished level as complete in the progress file at
+// path if the player won it. Lost levels leave the file untouched.
+func RecordLevelResult(path string, v GameView) (*CampaignProgress, error) {
+	progress, err := LoadCampaignProgress(path)
+	if err != nil {
+		return nil, err
+	}
+	level := v.Level()
+	if level == nil || v.EndReason() != GameEndLevelComplete || progress.IsComplete(level.ID) {
+		return progress, nil
+	}
+	progress.Completed[level.ID] = time.Now()
+	return progress, progress.Save(path)
+}
@@ -0,0 +1,55 @@
+package zcgame
+
+import "testing"
+
+func TestCampaignLevels(t *testing.T) {
+	for _, level := range Campaign {
+		completed := 0
+		for range 50 {
+			view, err := CreateLevelGame(level, "solo")
+			if err != nil {
+				t.Fatalf("failed to create level %s: %v", level.ID, err)
+			}
+			g, _ := playGame(t, view.game)
+			progress := view.LevelProgress()
+			switch g.EndReason {
+			case GameEndLevelComplete:
+				completed++
+				if progress.Nights < level.Goal.Nights || progress.Kills < level.Goal.Kills || progress.Lives < level.Goal.Lives {
+					t.Errorf("level %s completed with %+v, goal %+v", level.ID, progress, level.Goal)
+				}
+			case GameEndLevelFailed, GameEndAllEliminated, GameEndMaxNights, GameEndDeckExhausted:
+			default:
+				t.Errorf("level %s ended with %s", level.ID, g.EndReason)
+			}
+		}
+		t.Logf("%-10s completed %2d of 50 random games", level.ID, completed)
+	}
+}
+
+func TestLevelSetup(t *testing.T) {
+	level := Campaign[0]
+	view, err := CreateLevelGame(level, "solo")
+	if err != nil {
+		t.Fatalf("failed to create level: %v", err)
+	}
+	player := view.Player(0)
+	if player.Lives() != level.Lives || len(player.Stacks()) != len(level.Farm) {
+		t.Errorf("expected %d lives and farm %s, got %d lives and farm %s", level.Lives, level.Farm, player.Lives(), player.Stacks())
+	}
+	for i, item := range level.Hand {
+		if player.Hand()[i].FarmItemType != item {
+			t.Errorf("Hand[%d]: expected %s, got %s", i, item, player.Hand()[i].FarmItemType)
+		}
+	}
+	for i, name := range level.Script {
+		card := view.game.NightDeck[i]
+		if ZombieChickens[card.ZombieKey].Name != name {
+			t.Errorf("NightDeck[%d]: expected scripted %s", i, name)
+		}
+	}
+
+	if _, err := CreateLevelGame(Level{ID: "bad", Script: []string{"Nobody"}}, "solo"); err == nil {
+		t.Error("expected an error for an unknown night card")
+	}
+}
@@ -0,0 +1,234 @@
+package zcgame
+
+// Message keys for every prompt, label, tip and stage name the engine gives
+// out. Event descriptions are keyed by eventKey.
+const (
+	MsgPlain MessageKey = "plain" // An untranslated string, such as a name
+
+	// Day turn
+	MsgDiscard           MessageKey = "day.discard"
+	MsgPlayFirst         MessageKey = "day.play.first"
+	MsgPlaySecond        MessageKey = "day.play.second"
+	MsgPlayNth           MessageKey = "day.play.nth"
+	MsgPlayMove          MessageKey = "day.play.move"
+	MsgPlace             MessageKey = "day.place"
+	MsgPlaceCancel       MessageKey = "day.place.cancel"
+	MsgMoveCard          MessageKey = "day.move"
+	MsgMoveStack         MessageKey = "day.move.stack"
+	MsgMoveCancel        MessageKey = "day.move.cancel"
+	MsgMoveFrom          MessageKey = "day.move.from"
+	MsgMoveTo            MessageKey = "day.move.to"
+	MsgMoveNewStack      MessageKey = "day.move.new"
+	MsgDraw              MessageKey = "day.draw"
+	MsgDrawDeckOne       MessageKey = "day.draw.deck.one"
+	MsgDrawDeck          MessageKey = "day.draw.deck"
+	MsgDrawPublic        MessageKey = "day.draw.public"
+	MsgDrawPublicMoreOne MessageKey = "day.draw.public.more.one"
+	MsgDrawPublicMore    MessageKey = "day.draw.public.more"
+	MsgDrawScavengeOne   MessageKey = "day.draw.scavenge.one"
+	MsgDrawScavenge      MessageKey = "day.draw.scavenge"
+	MsgActionTarget      MessageKey = "day.action.target"
+	MsgSabotage          MessageKey = "day.action.sabotage"
+	MsgTrade             MessageKey = "day.action.trade"
+	MsgGiveTarget        MessageKey = "coop.give.target"
+	MsgGive              MessageKey = "coop.give"
+	MsgGiveRoom          MessageKey = "coop.give.room"
+	MsgGiveRefuse        MessageKey = "coop.give.refuse"
+	MsgMulligan          MessageKey = "setup.mulligan"
+	MsgMulliganRedraw    MessageKey = "setup.mulligan.redraw"
+	MsgMulliganKeep      MessageKey = "setup.mulligan.keep"
+	MsgDraft             MessageKey = "setup.draft"
+
+	// Night
+	MsgAutoKilled      MessageKey = "night.autokilled"
+	MsgLifeLoss        MessageKey = "night.lifeloss"
+	MsgLifeLossInfect  MessageKey = "night.lifeloss.infect"
+	MsgNoDefense       MessageKey = "night.nodefense"
+	MsgNoDefenseInfect MessageKey = "night.nodefense.infect"
+	MsgEliminated      MessageKey = "night.eliminated"
+	MsgShield          MessageKey = "night.shield"
+	MsgEvent           MessageKey = "night.event"
+	MsgEventDiscard    MessageKey = "night.event.discard"
+	MsgDefense         MessageKey = "night.defense"
+	MsgLend            MessageKey = "night.lend"
+	MsgBait            MessageKey = "night.bait"
+	MsgBaitKeep        MessageKey = "night.bait.keep"
+	MsgBossArrival     MessageKey = "night.boss"
+	MsgBossArrives     MessageKey = "night.boss.arrives"
+	MsgBossWrecks      MessageKey = "night.boss.wrecks"
+	MsgBossHatches     MessageKey = "night.boss.hatches"
+	MsgBossFight       MessageKey = "night.boss.fight"
+	MsgBossDefeated    MessageKey = "night.boss.defeated"
+	MsgBossOverrun     MessageKey = "night.boss.overrun"
+
+	// Tutorial tips
+	MsgTipAmmoOnShotgun MessageKey = "tip.ammo"
+	MsgTipHayWall       MessageKey = "tip.haywall"
+	MsgTipShield        MessageKey = "tip.shield"
+	MsgTipEvent         MessageKey = "tip.event"
+
+	// Stages in a turn
+	MsgStageDiscard    MessageKey = "stage.discard"
+	MsgStagePlay       MessageKey = "stage.play"
+	MsgStageDraw       MessageKey = "stage.draw"
+	MsgStageShare      MessageKey = "stage.share"
+	MsgStageChooseHand MessageKey = "stage.hand"
+	MsgStageNight      MessageKey = "stage.night"
+)
+
+// catalog holds the format string of every message in every language.
+var catalog = map[Language]map[MessageKey]string{
+	English: {
+		MsgPlain: "%v",
+
+		MsgDiscard:           "Discard a card to draw a replacement, or skip",
+		MsgPlayFirst:         "Select first card to play to your farm",
+		MsgPlaySecond:        "Select second card to play to your farm",
+		MsgPlayNth:           "Select card %d of %d to play to your farm",
+		MsgPlayMove:          "Move a card on your farm instead",
+		MsgPlace:             "Place %s: select a stack or start a new one",
+		MsgPlaceCancel:       "Choose another card",
+		MsgMoveCard:          "Choose a card on your farm to move",
+		MsgMoveStack:         "Move %s: select a stack or start a new one",
+		MsgMoveCancel:        "Leave it where it was",
+		MsgMoveFrom:          "%s from stack %d",
+		MsgMoveTo:            "Stack %d",
+		MsgMoveNewStack:      "A new stack",
+		MsgDraw:              "Choose how to draw cards",
+		MsgDrawDeckOne:       "Draw 1 card from the deck",
+		MsgDrawDeck:          "Draw %d cards from the deck",
+		MsgDrawPublic:        "Take the face-up cards %s",
+		MsgDrawPublicMoreOne: "Take the face-up cards %s and draw 1 card from the deck",
+		MsgDrawPublicMore:    "Take the face-up cards %s and draw %d cards from the deck",
+		MsgDrawScavengeOne:   "Scavenger: take %s and draw 1 card from the deck",
+		MsgDrawScavenge:      "Scavenger: take %s and draw %d cards from the deck",
+		MsgActionTarget:      "Choose a player to %s",
+		MsgSabotage:          "Choose a card to sabotage on %s's farm",
+		MsgTrade:             "Choose a card to give %s for a random card from their hand",
+		MsgGiveTarget:        "Choose a teammate to give %s to",
+		MsgGive:              "Give a card to a teammate and draw a replacement, or skip",
+		MsgGiveRoom:          "%s: your hand is full. Discard a card to take %s from %s, or refuse it",
+		MsgGiveRefuse:        "Refuse %s",
+		MsgMulligan:          "Keep your opening hand, or shuffle it back and draw %d new cards",
+		MsgMulliganRedraw:    "Redraw",
+		MsgMulliganKeep:      "Keep",
+		MsgDraft:             "Pick a card for your opening hand (%d of %d)",
+
+		MsgAutoKilled:      "%s: zombie auto-killed by %s!",
+		MsgLifeLoss:        "%s: will lose a life",
+		MsgLifeLossInfect:  "%s: will lose a life and %s gets infected",
+		MsgNoDefense:       "%s: no defense available, will lose a life",
+		MsgNoDefenseInfect: "%s: no defense available, will lose a life and %s gets infected",
+		MsgEliminated:      "%s has been eliminated!",
+		MsgShield:          "%s: use shield to save stack from exploding zombie?",
+		MsgEvent:           "%s: %s",
+		MsgEventDiscard:    "%s: choose card to discard (%d/%d)",
+		MsgDefense:         "%s: Select a stack to defend, or take damage",
+		MsgLend:            "%s: lend a stack to %s against %s, or refuse",
+		MsgBait:            "%s: use Bait to send %s to another player, or keep it",
+		MsgBaitKeep:        "Keep it",
+		MsgBossArrival:     "%s: %s",
+		MsgBossArrives:     "%s arrives with %d health!",
+		MsgBossWrecks:      "%s arrives and wrecks %s!",
+		MsgBossHatches:     "%s arrives and hatches a %s!",
+		MsgBossFight:       "%[1]s: %[2]s has %[3]d health left. Throw a stack at it (the stack is destroyed), or take %[3]d damage",
+		MsgBossDefeated:    "%s: %s has been defeated!",
+		MsgBossOverrun:     "%s: %s overruns the farm, will lose %d lives",
+
+		MsgTipAmmoOnShotgun: "Ammo and a Shotgun go in the same stack. Each time the stack blasts a zombie it uses up one Ammo; the Shotgun stays. Bulletproof and Invisible zombies can't be shot.",
+		MsgTipHayWall:       "Three Hay Bales make a Hay Wall. It stops any zombie that can't fly or climb, and it isn't used up. Exploding zombies still blow it apart.",
+		MsgTipShield:        "This zombie is Exploding: the stack that kills it is destroyed. A Shield on your farm can take the blast instead, so the stack survives.",
+		MsgTipEvent:         "Not every night card is a zombie. Events like this one hit every farm at once, then the night goes on.",
+
+		MsgStageDiscard:    "Discard a card to draw a card from the deck (optional)",
+		MsgStagePlay:       "Play %d cards to your farm",
+		MsgStageDraw:       "Draw %d cards from the deck or the %d face-up cards",
+		MsgStageShare:      "Give a card to a teammate's farm (optional)",
+		MsgStageChooseHand: "Choose your opening hand",
+		MsgStageNight:      "Progress through the night...",
+	},
+	Spanish: {
+		MsgPlain: "%v",
+
+		MsgDiscard:           "Descarta una carta para robar otra, o pasa",
+		MsgPlayFirst:         "Elige la primera carta para jugar en tu granja",
+		MsgPlaySecond:        "Elige la segunda carta para jugar en tu granja",
+		MsgPlayNth:           "Elige la carta %d de %d para jugar en tu granja",
+		MsgPlayMove:          "Mover una carta de tu granja",
+		MsgPlace:             "Coloca %s: elige una pila o empieza una nueva",
+		MsgPlaceCancel:       "Elegir otra carta",
+		MsgMoveCard:          "Elige una carta de tu granja para mover",
+		MsgMoveStack:         "Mueve %s: elige una pila o empieza una nueva",
+		MsgMoveCancel:        "Dejarla donde estaba",
+		MsgMoveFrom:          "%s de la pila %d",
+		MsgMoveTo:            "Pila %d",
+		MsgMoveNewStack:      "Una pila nueva",
+		MsgDraw:              "Elige cómo robar cartas",
+		MsgDrawDeckOne:       "Robar 1 carta del mazo",
+		MsgDrawDeck:          "Robar %d cartas del mazo",
+		MsgDrawPublic:        "Tomar las cartas boca arriba %s",
+		MsgDrawPublicMoreOne: "Tomar las cartas boca arriba %s y robar 1 carta del mazo",
+		MsgDrawPublicMore:    "Tomar las cartas boca arriba %s y robar %d cartas del mazo",
+		MsgDrawScavengeOne:   "Carroñero: tomar %s y robar 1 carta del mazo",
+		MsgDrawScavenge:      "Carroñero: tomar %s y robar %d cartas del mazo",
+		MsgActionTarget:      "Elige un jugador para %s",
+		MsgSabotage:          "Elige una carta para sabotear en la granja de %s",
+		MsgTrade:             "Elige una carta para darle a %s a cambio de una carta al azar de su mano",
+		MsgGiveTarget:        "Elige a qué compañero darle %s",
+		MsgGive:              "Da una carta a un compañero y roba otra, o pasa",
+		MsgGiveRoom:          "%s: tu mano está llena. Descarta una carta para aceptar %s de %s, o recházala",
+		MsgGiveRefuse:        "Rechazar %s",
+		MsgMulligan:          "Quédate con tu mano inicial, o barájala y roba %d cartas nuevas",
+		MsgMulliganRedraw:    "Volver a robar",
+		MsgMulliganKeep:      "Quedármela",
+		MsgDraft:             "Elige una carta para tu mano inicial (%d de %d)",
+
+		MsgAutoKilled:      "%s: ¡zombi eliminado automáticamente por %s!",
+		MsgLifeLoss:        "%s: perderá una vida",
+		MsgLifeLossInfect:  "%s: perderá una vida y %s se infecta",
+		MsgNoDefense:       "%s: sin defensa disponible, perderá una vida",
+		MsgNoDefenseInfect: "%s: sin defensa disponible, perderá una vida y %s se infecta",
+		MsgEliminated:      "¡%s ha sido eliminado!",
+		MsgShield:          "%s: ¿usar el escudo para salvar la pila del zombi explosivo?",
+		MsgEvent:           "%s: %s",
+		MsgEventDiscard:    "%s: elige una carta para descartar (%d/%d)",
+		MsgDefense:         "%s: Elige una pila para defenderte, o recibe el daño",
+		MsgLend:            "%s: presta una pila a %s contra %s, o niégate",
+		MsgBait:            "%s: usa el Cebo para enviar a %s a otro jugador, o quédatelo",
+		MsgBaitKeep:        "Quedármelo",
+		MsgBossArrival:     "%s: %s",
+		MsgBossArrives:     "¡%s llega con %d de vida!",
+		MsgBossWrecks:      "¡%s llega y destroza %s!",
+		MsgBossHatches:     "¡%s llega y hace eclosionar un %s!",
+		MsgBossFight:       "%[1]s: a %[2]s le quedan %[3]d de vida. Lánzale una pila (la pila se destruye), o recibe %[3]d de daño",
+		MsgBossDefeated:    "%s: ¡%s ha sido derrotado!",
+		MsgBossOverrun:     "%s: %s arrasa la granja, perderá %d vidas",
+
+		MsgTipAmmoOnShotgun: "La Munición y la Escopeta van en la misma pila. Cada vez que la pila dispara a un zombi gasta una Munición; la Escopeta se queda. A los zombis Antibalas e Invisibles no se les puede disparar.",
+		MsgTipHayWall:       "Tres Fardos de Heno forman un Muro de Heno. Detiene a cualquier zombi que no pueda volar ni trepar, y no se gasta. Los zombis Explosivos lo siguen volando por los aires.",
+		MsgTipShield:        "Este zombi es Explosivo: la pila que lo mata se destruye. Un Escudo en tu granja puede recibir la explosión en su lugar, y la pila sobrevive.",
+		MsgTipEvent:         "No todas las cartas de noche son zombis. Los eventos como este afectan a todas las granjas a la vez, y luego la noche continúa.",
+
+		MsgStageDiscard:    "Descarta una carta para robar otra del mazo (opcional)",
+		MsgStagePlay:       "Juega %d cartas en tu granja",
+		MsgStageDraw:       "Roba %d cartas del mazo o de las %d cartas boca arriba",
+		MsgStageShare:      "Da una carta a la granja de un compañero (opcional)",
+		MsgStageChooseHand: "Elige tu mano inicial",
+		MsgStageNight:      "La noche avanza...",
+
+		eventKey("Lightning Storm"):   "Todos los jugadores descartan 2 cartas de su granja.",
+		eventKey("Tornado"):           "Todos los jugadores descartan 3 cartas de su granja.",
+		eventKey("Blood Moon"):        "¡Los zombis acuden en masa esta noche!\nTodos los jugadores roban 3 cartas de noche más.",
+		eventKey("Winter Solstice"):   "¡Va a ser una noche larga! Todos los jugadores roban 2 cartas de noche más.",
+		eventKey("Squirrel Stampede"): "¡Una estampida de ardillas activa todas las Trampas! Todos los jugadores descartan las Trampas de su granja.",
+		eventKey("Heavy Rainfall"):    "¡El agua oxida los Lanzallamas! Todos los jugadores descartan los Lanzallamas y el Combustible de su granja.",
+		eventKey("Silent Night"):      "¡No hay más zombis esta noche! Todos los jugadores descartan las cartas de noche que les queden.",
+	},
+}
+
+// The English event descriptions are the ones in NightCardEvents.
+func init() {
+	for _, event := range NightCardEvents {
+		catalog[English][eventKey(event.Name)] = event.Description
+	}
+}
@@ -84,8 +84,69 @@ var ZombieChickens = map[int]ZombieChicken{
 		NumInDeck: 6,
 		Traits:    []ZombieTrait{Invisible},
 	},
+
+	// Zombie expansion
+	17: {
+		Name:      "Tank",
+		NumInDeck: 3,
+		Traits:    []ZombieTrait{Armored, Bulletproof},
+		Expansion: true,
+	},
+	18: {
+		Name:      "Horde",
+		NumInDeck: 4,
+		Traits:    []ZombieTrait{Swarm},
+		Expansion: true,
+	},
+	19: {
+		Name:      "Mole",
+		NumInDeck: 3,
+		Traits:    []ZombieTrait{Burrowing, Fireproof},
+		Expansion: true,
+	},
+	20: {
+		Name:      "Plague",
+		NumInDeck: 3,
+		Traits:    []ZombieTrait{Contagious, Climbing},
+		Expansion: true,
+	},
+	21: {
+		Name:      "Juggernaut",
+		NumInDeck: 2,
+		Traits:    []ZombieTrait{Armored, Burrowing, Exploding},
+		Expansion: true,
+	},
+	22: {
+		Name:      "Pox Swarm",
+		NumInDeck: 2,
+		Traits:    []ZombieTrait{Swarm, Contagious, Flying},
+		Expansion: true,
+	},
+
+	// Bosses (never in the night deck, see BossKeys)
+	100: {
+		Name:     "Rooster King",
+		Traits:   []ZombieTrait{Climbing},
+		Health:   3,
+		BossRule: BossRuleWreck,
+	},
+	101: {
+		Name:     "Mother Hen",
+		Traits:   []ZombieTrait{Fireproof},
+		Health:   2,
+		BossRule: BossRuleHatch,
+	},
+	102: {
+		Name:   "Storm Crow",
+		Traits: []ZombieTrait{Flying, Bulletproof},
+		Health: 3,
+	},
 }
 
+// BossKeys lists the ZombieChickens keys of the bosses in the order they appear
+// on boss nights. Bosses are never shuffled into the night deck.
+var BossKeys = []int{100, 101, 102}
+
 // FindStacksThatCanKill returns indices of all stacks that can defeat the given zombie.
 // It checks each stack against the zombie's traits to determine effectiveness.
 func (f *Farm) FindStacksThatCanKill(zc ZombieChicken) []int {
@@ -105,8 +166,9 @@ func (f *Farm) FindStacksThatCanKill(zc ZombieChicken) []int {
 			continue
 		}
 
-		// Shotgun + Ammo - beaten by Bulletproof or Invisible
-		if !zt.HasTrait(Bulletproof) && !zt.HasTrait(Invisible) && stack.HasItem(Shotgun) && stack.HasItem(Ammo) {
+		// Shotgun + Ammo - beaten by Bulletproof or Invisible (unless a Guard Dog is next to it)
+		seesZombie := !zt.HasTrait(Invisible) || f.hasGuardDogNextTo(i)
+		if !zt.HasTrait(Bulletproof) && seesZombie && stack.HasItem(Shotgun) && stack.HasItem(Ammo) {
 			result = append(result, i)
 			continue
 		}
@@ -117,8 +179,20 @@ func (f *Farm) FindStacksThatCanKill(zc ZombieChicken) []int {
 			continue
 		}
 
-		// BoobyTrap - beaten by Flying
-		if !zt.HasTrait(Flying) && stack.HasItem(BoobyTrap) {
+		// BoobyTrap - beaten by Flying or Burrowing
+		if !zt.HasTrait(Flying) && !zt.HasTrait(Burrowing) && stack.HasItem(BoobyTrap) {
+			result = append(result, i)
+			continue
+		}
+
+		// Pitchfork (worn or not) - beaten by Flying or Invisible
+		if !zt.HasTrait(Flying) && !zt.HasTrait(Invisible) && (stack.HasItem(Pitchfork) || stack.HasItem(WornPitchfork)) {
+			result = append(result, i)
+			continue
+		}
+
+		// Electric Fence + Generator - beaten by Flying
+		if !zt.HasTrait(Flying) && stack.HasItem(ElectricFence) && stack.HasItem(Generator) {
 			result = append(result, i)
 			continue
 		}
@@ -133,6 +207,16 @@ func (f *Farm) FindStacksThatCanKill(zc ZombieChicken) []int {
 	return result
 }
 
+// hasGuardDogNextTo returns true if a stack directly before or after stackIdx holds a Guard Dog.
+func (f *Farm) hasGuardDogNextTo(stackIdx int) bool {
+	for _, idx := range []int{stackIdx - 1, stackIdx + 1} {
+		if idx >= 0 && idx < len(f.Stacks) && f.Stacks[idx].HasItem(GuardDog) {
+			return true
+		}
+	}
+	return false
+}
+
 // HasTrait returns true if the trait slice contains the specified trait.
 func (zt ZombieTraits) HasTrait(trait ZombieTrait) bool {
 	for _, t := range zt {
@@ -149,8 +233,10 @@ func (zt ZombieTraits) HasTrait(trait ZombieTrait) bool {
 // A defense is "free" if:
 //   - The zombie is not Exploding (which destroys the stack)
 //   - The stack does not contain one-time-use items (BoobyTrap, WOLR, Ammo)
+//     or a Pitchfork, which wears out
 //
-// Free defenses include: Scarecrow (vs Timid), Hay Wall, Flamethrower+Fuel.
+// Free defenses include: Scarecrow (vs Timid), Hay Wall, Flamethrower+Fuel,
+// Electric Fence+Generator.
 func (f *Farm) FindStacksThatCanKillForFree(zc ZombieChicken) []int {
 	// Exploding zombies are never free - they destroy the stack
 	if zc.Traits.HasTrait(Exploding) {
@@ -167,8 +253,11 @@ func (f *Farm) FindStacksThatCanKillForFree(zc ZombieChicken) []int {
 		if stack.HasItem(BoobyTrap) || stack.HasItem(WOLR) || stack.HasItem(Ammo) {
 			continue
 		}
+		if stack.HasItem(Pitchfork) || stack.HasItem(WornPitchfork) {
+			continue
+		}
 
-		// Scarecrow, Hay Wall, Flamethrower+Fuel are free
+		// Scarecrow, Hay Wall, Flamethrower+Fuel, Electric Fence+Generator are free
 		result = append(result, idx)
 	}
 
@@ -189,11 +278,20 @@ func (s Stack) DescribeDefense(zc ZombieChicken) string {
 		return "Hay Wall"
 	}
 	if s.HasItem(Shotgun) && s.HasItem(Ammo) {
+		if zc.Traits.HasTrait(Invisible) {
+			return "Shotgun + Guard Dog"
+		}
 		return "Shotgun"
 	}
 	if s.HasItem(Flamethrower) && s.HasItem(Fuel) {
 		return "Flamethrower"
 	}
+	if s.HasItem(ElectricFence) && s.HasItem(Generator) {
+		return "Electric Fence"
+	}
+	if s.HasItem(Pitchfork) || s.HasItem(WornPitchfork) {
+		return "Pitchfork"
+	}
 	if s.HasItem(BoobyTrap) {
 		return "Booby Trap"
 	}
@@ -204,7 +302,9 @@ func (s Stack) DescribeDefense(zc ZombieChicken) string {
 // This handles all the side effects of using a defense:
 //   - WOLR destroys the entire farm
 //   - Exploding zombies destroy the stack (unless useShield is true)
-//   - One-time-use items (Ammo, BoobyTrap) are discarded
+//   - One-time-use items (Ammo, BoobyTrap) are discarded, unless the owner's
+//     Gunsmith or Mechanic role keeps them
+//   - A Pitchfork wears out on its first use and breaks on its second
 //   - Shield is consumed if useShield is true
 func (f *Farm) UseDefenseStack(stackIdx int, zc ZombieChicken, useShield bool, g *gameState) {
 	stack := f.Stacks[stackIdx]
@@ -232,25 +332,49 @@ func (f *Farm) UseDefenseStack(stackIdx int, zc ZombieChicken, useShield bool, g
 				}
 			}
 		} else {
-			// Discard all items in the destroyed stack
-			for _, item := range f.Stacks[stackIdx] {
-				g.discardDayCard(item)
-			}
-			f.Stacks[stackIdx] = Stack{}
-			f.clearStacks()
+			f.destroyStack(stackIdx, g)
 			return
 		}
 	}
 
 	// Remove one-time-use items
-	if stack.HasItem(Ammo) {
+	owner := g.farmOwner(f)
+	if stack.HasItem(Ammo) && !g.keepsAmmo(owner) {
 		f.Stacks[stackIdx].RemoveItem(Ammo)
 		g.discardDayCard(Ammo)
 	}
-	if stack.HasItem(BoobyTrap) {
+	if stack.HasItem(BoobyTrap) && !g.keepsBoobyTrap(owner) {
 		f.Stacks[stackIdx].RemoveItem(BoobyTrap)
 		g.discardDayCard(BoobyTrap)
 	}
+	if stack.HasItem(WornPitchfork) {
+		f.Stacks[stackIdx].RemoveItem(WornPitchfork)
+		g.discardDayCard(WornPitchfork)
+	} else if stack.HasItem(Pitchfork) {
+		f.Stacks[stackIdx].RemoveItem(Pitchfork)
+		f.Stacks[stackIdx] = append(f.Stacks[stackIdx], WornPitchfork)
+	}
+
+	f.clearStacks()
+}
 
+// destroyStack discards every item in the stack at stackIdx and removes the stack.
+func (f *Farm) destroyStack(stackIdx int, g *gameState) {
+	for _, item := range f.Stacks[stackIdx] {
+		g.discardDayCard(item)
+	}
+	f.Stacks[stackIdx] = Stack{}
 	f.clearStacks()
 }
+
+// weakestStack returns the index of the stack with the fewest items, or -1 if the farm is empty.
+// Ties go to the first such stack.
+func (f *Farm) weakestStack() int {
+	weakest := -1
+	for i, stack := range f.Stacks {
+		if weakest == -1 || len(stack) < len(f.Stacks[weakest]) {
+			weakest = i
+		}
+	}
+	return weakest
+}
@@ -0,0 +1,315 @@
+package zcgame
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestExpansionDayDeck(t *testing.T) {
+	for _, expansion := range []bool{false, true} {
+		deck := createDayDeck(GameOptions{DefenseExpansion: expansion}, rand.New(rand.NewSource(1)))
+		for item, amount := range ExpansionDayCardAmounts {
+			want := 0
+			if expansion {
+				want = amount
+			}
+			if got := countItemInStack(deck, item); got != want {
+				t.Errorf("expansion %v: expected %d %s in the day deck, got %d", expansion, want, item, got)
+			}
+		}
+	}
+}
+
+func TestPitchforkWearsOut(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{DefenseExpansion: true}, "a", "b")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	g := view.game
+	farm := g.Players[0].Farm
+	farm.Stacks = Stacks{{Pitchfork}}
+
+	for _, zc := range []ZombieChicken{
+		{Name: "Flyer", Traits: ZombieTraits{Flying}},
+		{Name: "Ghost", Traits: ZombieTraits{Invisible}},
+	} {
+		if kills := farm.FindStacksThatCanKill(zc); len(kills) != 0 {
+			t.Errorf("a Pitchfork shouldn't beat %s, got stacks %v", zc.Name, kills)
+		}
+	}
+
+	zc := ZombieChicken{Name: "Climber", Traits: ZombieTraits{Climbing}}
+	if kills := farm.FindStacksThatCanKill(zc); !slices.Equal(kills, []int{0}) {
+		t.Fatalf("a Pitchfork should beat a Climbing zombie, got stacks %v", kills)
+	}
+	farm.UseDefenseStack(0, zc, false, g)
+	if len(farm.Stacks) != 1 || !farm.Stacks[0].HasItem(WornPitchfork) {
+		t.Fatalf("a Pitchfork should wear out on its first use, got %s", farm.Stacks)
+	}
+	if kills := farm.FindStacksThatCanKill(zc); !slices.Equal(kills, []int{0}) {
+		t.Fatalf("a worn Pitchfork should still beat a Climbing zombie, got stacks %v", kills)
+	}
+	farm.UseDefenseStack(0, zc, false, g)
+	if len(farm.Stacks) != 0 {
+		t.Fatalf("a worn Pitchfork should break on its second use, got %s", farm.Stacks)
+	}
+	if g.DiscardedDayCards[Pitchfork] != 1 || g.DiscardedDayCards[WornPitchfork] != 0 {
+		t.Errorf("a broken Pitchfork should be discarded as a fresh one, got %v", g.DiscardedDayCards)
+	}
+}
+
+func TestGuardDogNextToShotgun(t *testing.T) {
+	ghost := ZombieChicken{Name: "Ghost", Traits: ZombieTraits{Invisible}}
+	tests := []struct {
+		stacks Stacks
+		kills  []int
+	}{
+		{Stacks{{Shotgun, Ammo}}, []int{}},
+		{Stacks{{Shotgun, Ammo}, {GuardDog}}, []int{0}},
+		{Stacks{{GuardDog}, {Shotgun, Ammo}}, []int{1}},
+		{Stacks{{Shotgun, Ammo}, {Scarecrow}, {GuardDog}}, []int{}},
+		{Stacks{{Flamethrower, Fuel}, {GuardDog}}, []int{}},
+	}
+	for _, tt := range tests {
+		farm := &Farm{Stacks: tt.stacks}
+		if kills := farm.FindStacksThatCanKill(ghost); !slices.Equal(kills, tt.kills) {
+			t.Errorf("%s against an Invisible zombie: expected stacks %v, got %v", tt.stacks, tt.kills, kills)
+		}
+	}
+}
+
+func TestElectricFenceNeedsGenerator(t *testing.T) {
+	zombie := ZombieChicken{Name: "Biter", Traits: ZombieTraits{Climbing}}
+	flyer := ZombieChicken{Name: "Flyer", Traits: ZombieTraits{Flying}}
+
+	farm := &Farm{}
+	farm.PlayCard(ElectricFence, PlayerPlayChoices{})
+	if kills := farm.FindStacksThatCanKill(zombie); len(kills) != 0 {
+		t.Fatalf("an Electric Fence without a Generator shouldn't kill, got stacks %v", kills)
+	}
+	farm.PlayCard(Generator, PlayerPlayChoices{})
+	if len(farm.Stacks) != 1 {
+		t.Fatalf("a Generator should power the unpowered Electric Fence, got %s", farm.Stacks)
+	}
+	if kills := farm.FindStacksThatCanKill(zombie); !slices.Equal(kills, []int{0}) {
+		t.Errorf("a powered Electric Fence should beat a Climbing zombie, got stacks %v", kills)
+	}
+	if kills := farm.FindStacksThatCanKill(flyer); len(kills) != 0 {
+		t.Errorf("a powered Electric Fence shouldn't beat a Flying zombie, got stacks %v", kills)
+	}
+}
+
+func TestDefenseExpansionGames(t *testing.T) {
+	for seed := range int64(20) {
+		simulateGame(t, GameOptions{DefenseExpansion: true, Seed: seed + 1}, "a", "b", "c")
+	}
+}
+
+// startNight deals each player the given night cards, in seat order, and
+// starts the night. Returns the first night prompt.
+func startNight(t *testing.T, g *gameState, cards ...NightCards) *PlayerInputNeeded {
+	t.Helper()
+	for i, player := range g.Players {
+		player.Farm.NightCards = nil
+		if i < len(cards) {
+			player.Farm.NightCards = cards[i]
+		}
+	}
+	g.Turn = Night
+	g.CurrentPlayerIdx = 0
+	g.NightCardsDealt = true
+	g.NightSubStage = NightSubStageProcessCards
+	g.NightPlayerIndex = 0
+	g.NightPlayersToProcess = len(g.Players)
+	g.NightAnyCardProcessed = false
+	_, input := g.ContinueDay()
+	if input == nil {
+		t.Fatal("expected a night prompt")
+	}
+	return input
+}
+
+// newTestGame creates a game for tests that set up the farms themselves.
+func newTestGame(t *testing.T, options GameOptions, playerNames ...string) *gameState {
+	t.Helper()
+	view, err := CreateNewGameWithOptions(options, playerNames...)
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	for _, player := range view.game.Players {
+		player.Farm.Stacks = Stacks{}
+	}
+	return view.game
+}
+
+func TestBurrowingZombie(t *testing.T) {
+	mole := ZombieChickens[19]
+	if kills := (&Farm{Stacks: Stacks{{BoobyTrap}}}).FindStacksThatCanKill(mole); len(kills) != 0 {
+		t.Errorf("a Booby Trap shouldn't beat a Burrowing zombie, got stacks %v", kills)
+	}
+	if kills := (&Farm{Stacks: Stacks{{HayBale, HayBale, HayBale}}}).FindStacksThatCanKill(mole); len(kills) != 1 {
+		t.Errorf("a Hay Wall should beat a Burrowing zombie, got stacks %v", kills)
+	}
+}
+
+func TestArmoredZombieDefendedTwice(t *testing.T) {
+	g := newTestGame(t, GameOptions{ZombieExpansion: true}, "a", "b")
+	g.Players[0].Farm.Stacks = Stacks{{HayBale, HayBale, HayBale}}
+	input := startNight(t, g, NightCards{{ZombieKey: 17}})
+	if g.NightSubStage != NightSubStageZombieAutoKilled {
+		t.Fatalf("expected the Hay Wall to hold off the Tank, got %q", input.Message)
+	}
+
+	_, input = g.ContinueAfterInput(0)
+	cards := g.Players[0].Farm.NightCards
+	if len(cards) != 1 || cards[0].ZombieKey != 17 || cards[0].Resolved != 1 {
+		t.Fatalf("the Tank should come back after its first attack, got %v", cards)
+	}
+	if g.ZombiesKilled != 0 {
+		t.Fatal("the Tank shouldn't count as killed after one defense")
+	}
+	if input == nil || g.NightSubStage != NightSubStageZombieAutoKilled {
+		t.Fatal("expected the Tank to attack again")
+	}
+
+	g.ContinueAfterInput(0)
+	if len(g.Players[0].Farm.NightCards) != 0 || g.ZombiesKilled != 1 {
+		t.Fatalf("the Tank should be killed by the second defense, got %v and %d kills", g.Players[0].Farm.NightCards, g.ZombiesKilled)
+	}
+}
+
+func TestSwarmAttacksTwice(t *testing.T) {
+	g := newTestGame(t, GameOptions{ZombieExpansion: true}, "a", "b")
+	lives := g.Players[0].Lives
+	input := startNight(t, g, NightCards{{ZombieKey: 18}})
+	for input != nil {
+		_, input = g.ContinueAfterInput(input.ValidChoices[0])
+	}
+	if g.Players[0].Lives != lives-2 {
+		t.Errorf("an undefended Swarm should cost two lives, went from %d to %d", lives, g.Players[0].Lives)
+	}
+}
+
+func TestContagiousZombieInfectsNeighbor(t *testing.T) {
+	g := newTestGame(t, GameOptions{ZombieExpansion: true}, "a", "b", "c")
+	lives := g.Players[0].Lives
+	startNight(t, g, NightCards{{ZombieKey: 20}})
+	g.ContinueAfterInput(0)
+	if g.Players[0].Lives != lives-1 {
+		t.Errorf("expected the Plague to cost a life, went from %d to %d", lives, g.Players[0].Lives)
+	}
+	if cards := g.Players[1].Farm.NightCards; len(cards) != 1 || !cards[0].IsZombie() {
+		t.Errorf("the next player should be infected with a zombie, got %v", cards)
+	}
+	if cards := g.Players[2].Farm.NightCards; len(cards) != 0 {
+		t.Errorf("only the next player should be infected, got %v", cards)
+	}
+}
+
+func TestLetThroughZombieDiscard(t *testing.T) {
+	for _, expansion := range []bool{false, true} {
+		g := newTestGame(t, GameOptions{ZombieExpansion: expansion}, "a", "b")
+		g.Players[0].Farm.Stacks = Stacks{{Shotgun, Ammo}}
+		discarded := len(g.DiscardedNightCards)
+
+		input := startNight(t, g, NightCards{{ZombieKey: 8}})
+		if input.Context != InputContextDefense {
+			t.Fatalf("expected a defense prompt, got %q", input.Message)
+		}
+		g.ContinueAfterInput(-1) // Take the hit
+		g.ContinueAfterInput(0)
+
+		// The base game takes the zombie out of play; the expansion discards it
+		want := discarded
+		if expansion {
+			want++
+		}
+		if got := len(g.DiscardedNightCards); got != want {
+			t.Errorf("expansion %v: expected %d discarded night cards, got %d", expansion, want, got)
+		}
+	}
+}
+
+func TestZombieExpansionGames(t *testing.T) {
+	for seed := range int64(20) {
+		simulateGame(t, GameOptions{ZombieExpansion: true, Seed: seed + 1}, "a", "b", "c")
+	}
+}
+
+func TestBossNights(t *testing.T) {
+	g := newTestGame(t, GameOptions{BossEvery: 2}, "a", "b")
+	want := map[int]int{2: 100, 4: 101, 6: 102, 8: 100}
+	for night := 1; night <= 8; night++ {
+		g.NightNum = night
+		boss, ok := want[night]
+		if g.isBossNight() != ok {
+			t.Errorf("night %d: expected boss night %v", night, ok)
+		} else if ok && g.tonightsBoss() != boss {
+			t.Errorf("night %d: expected %s, got %s", night, ZombieChickens[boss].Name, ZombieChickens[g.tonightsBoss()].Name)
+		}
+	}
+}
+
+func TestBossWrecksWeakestStack(t *testing.T) {
+	g := newTestGame(t, GameOptions{BossEvery: 1}, "a", "b")
+	player := g.Players[0]
+	player.Farm.Stacks = Stacks{{HayBale, HayBale, HayBale}, {Scarecrow}}
+	lives := player.Lives
+
+	startNight(t, g, NightCards{{ZombieKey: 100}})
+	if g.NightSubStage != NightSubStageBossArrival {
+		t.Fatalf("expected the Rooster King to arrive, got sub-stage %d", g.NightSubStage)
+	}
+	if len(player.Farm.Stacks) != 1 || countItemInStack(player.Farm.Stacks[0], HayBale) != 3 {
+		t.Fatalf("the Rooster King should wreck the Scarecrow, got %s", player.Farm.Stacks)
+	}
+
+	// A Hay Wall can't stop a Climbing boss, so it overruns the farm
+	g.ContinueAfterInput(0)
+	if g.NightSubStage != NightSubStageBossOverrun {
+		t.Fatalf("expected the Rooster King to overrun the farm, got sub-stage %d", g.NightSubStage)
+	}
+	g.ContinueAfterInput(0)
+	if player.Lives != lives-3 {
+		t.Errorf("an overrun should cost the boss's health in lives, went from %d to %d", lives, player.Lives)
+	}
+}
+
+func TestBossFight(t *testing.T) {
+	g := newTestGame(t, GameOptions{BossEvery: 1}, "a", "b")
+	player := g.Players[0]
+	player.Farm.Stacks = Stacks{{Shotgun, Ammo}, {Shotgun, Ammo}}
+	lives := player.Lives
+
+	startNight(t, g, NightCards{{ZombieKey: 101}})
+	if cards := player.Farm.NightCards; len(cards) != 2 || !cards[1].IsZombie() {
+		t.Fatalf("Mother Hen should hatch a zombie behind her, got %v", cards)
+	}
+	_, input := g.ContinueAfterInput(0)
+	for range 2 {
+		if g.NightSubStage != NightSubStageBossFight {
+			t.Fatalf("expected to fight Mother Hen, got sub-stage %d", g.NightSubStage)
+		}
+		_, input = g.ContinueAfterInput(input.ValidChoices[0])
+	}
+	if g.NightSubStage != NightSubStageBossDefeated {
+		t.Fatalf("two stacks should defeat Mother Hen, got sub-stage %d", g.NightSubStage)
+	}
+	if len(player.Farm.Stacks) != 0 {
+		t.Errorf("stacks thrown at a boss should be destroyed, got %s", player.Farm.Stacks)
+	}
+	g.ContinueAfterInput(0)
+	if player.Lives != lives || g.ZombiesKilled != 1 {
+		t.Errorf("expected no lives lost and one kill, got %d lives and %d kills", player.Lives, g.ZombiesKilled)
+	}
+	if cards := player.Farm.NightCards; len(cards) != 1 || ZombieChickens[cards[0].ZombieKey].IsBoss() {
+		t.Errorf("only the hatched zombie should be left, got %v", cards)
+	}
+}
+
+func TestBossGames(t *testing.T) {
+	for seed := range int64(20) {
+		simulateGame(t, GameOptions{BossEvery: 2, Seed: seed + 1}, "a", "b", "c")
+	}
+}
@@ -0,0 +1,217 @@
+package zcgame
+
+// Co-op Mode
+//
+// In co-op (GameOptions.Mode == ModeCoop) the players are one team:
+//   - Every player's lives go into a shared pool, which each Player.Lives mirrors.
+//     Running it dry loses the game for everyone; nobody is eliminated on their own.
+//   - After the Afternoon comes the Day turn, where each player may give one card
+//     from their hand to a teammate's hand and draw a replacement. A teammate
+//     with a full hand discards a card to take the gift, or refuses it.
+//   - At night, a player with no stack that can beat their zombie may be lent one
+//     by a neighbor, who decides whether to spend it.
+//   - The team wins by surviving CoopNights nights.
+
+// isCoop returns true if the game is played in co-op mode.
+func (g *gameState) isCoop() bool {
+	return g.Options.Mode == ModeCoop
+}
+
+// coopNights returns the number of nights a co-op team must survive.
+func (g *gameState) coopNights() int {
+	if g.Options.CoopNights > 0 {
+		return g.Options.CoopNights
+	}
+	return DefaultCoopNights
+}
+
+// teammates returns the indices of every player other than the current one.
+func (g *gameState) teammates() []int {
+	result := []int{}
+	for i := range g.Players {
+		if i != g.CurrentPlayerIdx {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// doCoopDayTurn processes one player's co-op Day turn using the same state machine
+// pattern as doPlayerDayTurn. Returns nil when the turn is complete, or
+// *PlayerInputNeeded when input is required.
+func (g *gameState) doCoopDayTurn() *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+	g.StageInTurn = ShareCards
+
+	switch g.DaySubStage {
+	case DaySubStageGiveTarget:
+		choices := []int{}
+		labels := map[int]Text{}
+		for _, idx := range g.teammates() {
+			choices = append(choices, idx+1)
+			labels[idx+1] = plainText(g.Players[idx].Name)
+		}
+		return &PlayerInputNeeded{
+			Context:      InputContextChoosePlayer,
+			RenderType:   RenderNormal,
+			Text:         NewText(MsgGiveTarget, g.PendingCardItem),
+			ValidChoices: choices,
+			LabelTexts:   labels,
+		}
+
+	case DaySubStageGiveRoom:
+		return g.createGiveRoomInput()
+	}
+
+	// Nothing to give, or nobody to give it to
+	if len(g.Players) < 2 || player.Hand.Count() == 0 {
+		g.endCoopDayTurn()
+		return nil
+	}
+
+	g.DaySubStage = DaySubStageGiveCard
+	player.Hand.Sort()
+	return &PlayerInputNeeded{
+		Context:      InputContextGive,
+		RenderType:   RenderNormal,
+		Text:         NewText(MsgGive),
+		ValidChoices: append(player.Hand.choices(), 0),
+	}
+}
+
+// createGiveRoomInput asks a teammate with a full hand which card to discard
+// to make room for the gift, or 0 to refuse it. Hand indices refer to the
+// teammate's hand, which the labels list.
+func (g *gameState) createGiveRoomInput() *PlayerInputNeeded {
+	teammate := g.Players[g.GiveTargetIdx]
+	teammate.Hand.Sort()
+
+	labels := map[int]Text{0: NewText(MsgGiveRefuse, g.PendingCardItem)}
+	for _, choice := range teammate.Hand.choices() {
+		labels[choice] = plainText(teammate.Hand[choice-1].FarmItemType.String())
+	}
+	return &PlayerInputNeeded{
+		Context:      InputContextDiscard,
+		RenderType:   RenderNormal,
+		Text:         NewText(MsgGiveRoom, teammate.Name, g.PendingCardItem, g.CurrentPlayer().Name),
+		ValidChoices: append(teammate.Hand.choices(), 0),
+		LabelTexts:   labels,
+		Item:         g.PendingCardItem,
+	}
+}
+
+// provideCoopDayInput handles input during the co-op Day turn.
+func (g *gameState) provideCoopDayInput(choice int) *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+
+	switch g.DaySubStage {
+	case DaySubStageGiveCard:
+		if choice == 0 {
+			g.endCoopDayTurn()
+			return nil
+		}
+		g.PendingCardItem = player.Hand[choice-1].FarmItemType
+		if teammates := g.teammates(); len(teammates) == 1 {
+			return g.giveCardTo(teammates[0])
+		}
+		g.DaySubStage = DaySubStageGiveTarget
+		return g.doCoopDayTurn()
+
+	case DaySubStageGiveTarget:
+		return g.giveCardTo(choice - 1)
+
+	case DaySubStageGiveRoom:
+		if choice == 0 {
+			// The gift stays in the giver's hand
+			g.endCoopDayTurn()
+			return nil
+		}
+		teammate := g.Players[g.GiveTargetIdx]
+		g.discardDayCard(teammate.Hand[choice-1].FarmItemType)
+		teammate.Hand[choice-1] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+		g.finishGive()
+		return nil
+	}
+
+	return nil
+}
+
+// giveCardTo gives the pending card to the teammate, asking them to make room
+// first if their hand is full.
+func (g *gameState) giveCardTo(teammateIdx int) *PlayerInputNeeded {
+	g.GiveTargetIdx = teammateIdx
+	teammate := g.Players[teammateIdx]
+	if teammate.Hand.Count() >= len(teammate.Hand) {
+		g.DaySubStage = DaySubStageGiveRoom
+		return g.doCoopDayTurn()
+	}
+	g.finishGive()
+	return nil
+}
+
+// finishGive moves the given card from the current player's hand to the
+// teammate's, draws a replacement and ends their Day turn. The teammate's hand
+// must have room.
+func (g *gameState) finishGive() {
+	teammate := g.Players[g.GiveTargetIdx]
+	teammate.Hand.add(g.PendingCardItem)
+	teammate.Hand.Sort()
+
+	player := g.CurrentPlayer()
+	for i := range player.Hand {
+		if player.Hand[i].FarmItemType == g.PendingCardItem {
+			player.Hand[i] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+			break
+		}
+	}
+	player.Hand.add(g.nextDayCard())
+	player.Hand.Sort()
+	g.endCoopDayTurn()
+}
+
+// endCoopDayTurn resets the day sub-stage and advances to the next player.
+func (g *gameState) endCoopDayTurn() {
+	g.DaySubStage = DaySubStageOptionalDiscard
+	g.PlaysMade = 0
+	g.PlayerTurnIndex++
+	g.nextPlayer()
+}
+
+// findLender returns the index of a neighbor (next player first, then previous)
+// with a stack that can beat the zombie, or -1 if nobody can help.
+// Only co-op players lend stacks, and never against a boss.
+func (g *gameState) findLender(zc ZombieChicken) int {
+	if !g.isCoop() || zc.IsBoss() || len(g.Players) < 2 {
+		return -1
+	}
+	next := (g.CurrentPlayerIdx + 1) % len(g.Players)
+	prev := (g.CurrentPlayerIdx + len(g.Players) - 1) % len(g.Players)
+	for _, idx := range []int{next, prev} {
+		if idx != g.CurrentPlayerIdx && len(g.Players[idx].Farm.FindStacksThatCanKill(zc)) > 0 {
+			return idx
+		}
+	}
+	return -1
+}
+
+// createLendInput asks the lender which stack to spend on the current player's zombie.
+func (g *gameState) createLendInput() *PlayerInputNeeded {
+	lender := g.Players[g.LenderIdx]
+	zc := *g.CurrentZombie
+	allStacks := lender.Farm.FindStacksThatCanKill(zc)
+
+	// Convert to 1-based indices for display, add 0 to refuse
+	choices := make([]int, 0, len(allStacks)+1)
+	for _, idx := range allStacks {
+		choices = append(choices, idx+1)
+	}
+	choices = append(choices, 0)
+
+	return &PlayerInputNeeded{
+		Context:      InputContextLend,
+		RenderType:   RenderForNight,
+		Text:         NewText(MsgLend, lender.Name, g.CurrentPlayer().Name, zc.Name),
+		ValidChoices: choices,
+		ValidStacks:  allStacks,
+	}
+}
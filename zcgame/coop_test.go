@@ -0,0 +1,121 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLendDefense(t *testing.T) {
+	for _, lend := range []bool{true, false} {
+		g := newTestGame(t, GameOptions{Mode: ModeCoop}, "a", "b", "c")
+		g.Players[1].Farm.Stacks = Stacks{{Shotgun, Ammo}}
+		lives := g.Players[0].Lives
+
+		input := startNight(t, g, NightCards{{ZombieKey: 8}})
+		if input.Context != InputContextLend || g.LenderIdx != 1 {
+			t.Fatalf("expected the next player to be asked to lend, got %q", input.Message)
+		}
+		if !lend {
+			_, input = g.ContinueAfterInput(0)
+			g.ContinueAfterInput(input.ValidChoices[0])
+			for i, player := range g.Players {
+				if player.Lives != lives-1 {
+					t.Errorf("Players[%d] should share the lost life, got %d of %d lives", i, player.Lives, lives)
+				}
+			}
+			continue
+		}
+
+		g.ContinueAfterInput(1)
+		if g.Players[0].Lives != lives || g.ZombiesKilled != 1 {
+			t.Errorf("a lent Shotgun should kill the zombie, got %d of %d lives and %d kills", g.Players[0].Lives, lives, g.ZombiesKilled)
+		}
+		if g.Players[1].Farm.Stacks[0].HasItem(Ammo) {
+			t.Error("the lender's Ammo should be used up")
+		}
+	}
+}
+
+func TestGiveCard(t *testing.T) {
+	g := newTestGame(t, GameOptions{Mode: ModeCoop, Seed: 3}, "a", "b", "c")
+	_, input := g.ContinueDay()
+	for input == nil || input.Context != InputContextGive {
+		if input == nil {
+			_, input = g.ContinueDay()
+			continue
+		}
+		_, input = g.ContinueAfterInput(input.ValidChoices[0])
+	}
+	if g.Turn != Day {
+		t.Fatalf("cards are given in the Day turn, got %s", g.Turn)
+	}
+
+	giver := g.CurrentPlayer()
+	handSize := giver.Hand.Count()
+	item := giver.Hand[0].FarmItemType
+	_, input = g.ContinueAfterInput(1)
+	if input == nil || input.Context != InputContextChoosePlayer || len(input.ValidChoices) != 2 {
+		t.Fatalf("expected to choose between the two teammates, got %v", input)
+	}
+	targetIdx := input.ValidChoices[0] - 1
+	target := g.Players[targetIdx]
+	snapshot := g.clone()
+
+	// The teammate's hand is full after the Afternoon draw, so they make room
+	_, input = g.ContinueAfterInput(targetIdx + 1)
+	if input == nil || input.Context != InputContextDiscard || g.activeInputPlayerIdx() != targetIdx {
+		t.Fatalf("expected %s to discard a card for the gift, got %v", target.Name, input)
+	}
+	dropped := target.Hand[0].FarmItemType
+	if dropped == item {
+		dropped = target.Hand[1].FarmItemType
+	}
+	g.ContinueAfterInput(handIndex(t, target.Hand, dropped))
+	if countHand(target.Hand, item) != countHand(snapshot.Players[targetIdx].Hand, item)+1 || target.Hand.Count() != len(target.Hand) {
+		t.Errorf("%s should be in %s's hand, got %s", item, target.Name, target.Hand)
+	}
+	if len(target.Farm.Stacks) != len(snapshot.Players[targetIdx].Farm.Stacks) {
+		t.Errorf("a gift shouldn't be played onto %s's farm, got %s", target.Name, target.Farm.Stacks)
+	}
+	if giver.Hand.Count() != handSize {
+		t.Errorf("the giver should draw a replacement, got %d cards instead of %d", giver.Hand.Count(), handSize)
+	}
+
+	// Refusing the gift leaves both hands alone
+	g = snapshot
+	giver, target = g.CurrentPlayer(), g.Players[targetIdx]
+	hand, targetHand := slices.Clone(giver.Hand), slices.Clone(target.Hand)
+	g.ContinueAfterInput(targetIdx + 1)
+	g.ContinueAfterInput(0)
+	if !slices.Equal(giver.Hand, hand) || !slices.Equal(target.Hand, targetHand) {
+		t.Errorf("a refused gift should stay with the giver, got %s and %s", giver.Hand, target.Hand)
+	}
+}
+
+func TestNoLendingInCompetitiveGames(t *testing.T) {
+	g := newTestGame(t, GameOptions{}, "a", "b", "c")
+	g.Players[1].Farm.Stacks = Stacks{{Shotgun, Ammo}}
+	if input := startNight(t, g, NightCards{{ZombieKey: 8}}); input.Context == InputContextLend {
+		t.Fatal("only co-op players lend stacks")
+	}
+}
+
+func TestCoopGames(t *testing.T) {
+	for seed := range int64(20) {
+		g, _ := simulateGame(t, GameOptions{Mode: ModeCoop, CoopNights: 4, Seed: seed + 1}, "a", "b", "c")
+		switch g.EndReason {
+		case GameEndTeamSurvived:
+			if g.NightNum < 4 {
+				t.Errorf("the team won on night %d, before surviving 4 nights", g.NightNum)
+			}
+		case GameEndTeamDefeated:
+			for _, player := range g.Players {
+				if player.Lives > 0 {
+					t.Errorf("the team lost with %s still on %d lives", player.Name, player.Lives)
+				}
+			}
+		default:
+			t.Errorf("a co-op game should end in a win or a loss for the team, got %s", g.EndReason)
+		}
+	}
+}
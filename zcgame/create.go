@@ -1,28 +1,141 @@
 package zcgame
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+)
 
 // DebugMode puts events on top of night deck for testing
 var DebugMode = false
 
-// createDayDeck creates a shuffled deck of day cards based on DayCardAmounts.
-func createDayDeck() Stack {
-	var deck = Stack{}
-	for farmItem, amount := range DayCardAmounts {
-		for range amount {
-			deck = append(deck, farmItem)
+// GameOptions configures optional rules for a new game.
+// The zero value plays the base game.
+type GameOptions struct {
+	DefenseExpansion bool // Adds Pitchfork, Guard Dog, Electric Fence and Generator to the day deck
+	ZombieExpansion  bool // Adds Armored, Swarm, Burrowing and Contagious zombies to the night deck
+	InteractionCards bool // Adds Bait, Sabotage and Trade to the day deck
+	Roles            bool // Deals every player a farmer role with a special ability
+	Reorganize       bool // Lets a player spend a play moving a card between their farm stacks
+	BossEvery        int  // Every BossEvery-th night each player also faces a boss (0 disables bosses)
+
+	DeckExhaustion DeckExhaustionRule // What happens when a deck and its discard pile are both empty
+	MaxNights      int                // Competitive only: game ends after this night if one player has the most lives (0 for no limit)
+
+	Mode       GameMode // Competitive (every farm for itself), co-op, teams or solo
+	CoopNights int      // Co-op only: nights the team must survive to win (0 uses DefaultCoopNights)
+	Teams      []int    // Teams only: team number of each seat in player order (nil alternates seats between two teams)
+
+	Seed     int64 // Seeds every shuffle, so the same seed and options deal the same cards (0 picks a random seed)
+	Tutorial bool  // Explains rules the first time they come up; see PlayerInputNeeded.Tip
+
+	Setup SetupVariant // How opening hands are dealt: straight from the deck, with a mulligan, or drafted
+
+	SimultaneousDiscards bool // Every player picks their event discards at once instead of in turn; see PendingInputs
+
+	Rules Rules // Hand size, plays and draws per turn, face-up cards, starting lives and night cards (zero fields keep the printed rules)
+}
+
+// GameMode selects how players win.
+type GameMode uint8
+
+const (
+	ModeCompetitive GameMode = iota // Last farm standing wins
+	ModeCoop                        // Players share their lives and win together by surviving
+	ModeTeams                       // Seats form teams that share their lives; last team standing wins
+	ModeSolo                        // One player scores nights survived, zombies killed and cards left
+)
+
+// ParseGameMode parses the String form of a GameMode.
+func ParseGameMode(s string) (GameMode, error) {
+	for mode := ModeCompetitive; mode <= ModeSolo; mode++ {
+		if mode.String() == s {
+			return mode, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown game mode %q (want competitive, co-op, teams or solo)", s)
+}
+
+// DefaultCoopNights is how many nights a co-op team must survive when
+// GameOptions.CoopNights is not set.
+const DefaultCoopNights = 8
+
+// DeckExhaustionRule decides what happens when a deck and its discard pile are both empty,
+// which can happen late in big games when most cards sit in farms and hands.
+type DeckExhaustionRule uint8
+
+const (
+	DeckExhaustionReshuffle DeckExhaustionRule = iota // Shuffle in the starting cards that have left play; draw nothing if none have
+	DeckExhaustionSkip                                // Draw nothing: the hand slot stays empty, or no night card is dealt
+	DeckExhaustionEndGame                             // End the game; Standings decides the winner
+)
+
+// ParseDeckExhaustionRule parses the String form of a DeckExhaustionRule.
+func ParseDeckExhaustionRule(s string) (DeckExhaustionRule, error) {
+	for rule := DeckExhaustionReshuffle; rule <= DeckExhaustionEndGame; rule++ {
+		if rule.String() == s {
+			return rule, nil
 		}
 	}
+	return 0, fmt.Errorf("unknown deck exhaustion rule %q (want reshuffle, skip or end)", s)
+}
+
+// ParseTeams parses a comma separated list of 1-based team numbers, one per
+// seat (e.g. "1,2,1,2"), into GameOptions.Teams.
+func ParseTeams(s string) ([]int, error) {
+	teams := []int{}
+	for _, field := range strings.Split(s, ",") {
+		team, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || team < 1 {
+			return nil, fmt.Errorf("invalid team %q (want team numbers like 1,2,1,2)", field)
+		}
+		teams = append(teams, team-1)
+	}
+	return teams, nil
+}
+
+// createDayDeck creates a shuffled deck of day cards based on DayCardAmounts,
+// plus ExpansionDayCardAmounts when the defense expansion is enabled and
+// InteractionDayCardAmounts when interaction cards are enabled.
+func createDayDeck(options GameOptions, rng *rand.Rand) Stack {
+	var deck = addDayCards(Stack{}, DayCardAmounts)
+	if options.DefenseExpansion {
+		deck = addDayCards(deck, ExpansionDayCardAmounts)
+	}
+	if options.InteractionCards {
+		deck = addDayCards(deck, InteractionDayCardAmounts)
+	}
 
-	shuffle(deck)
+	shuffle(rng, deck)
 
 	return deck
 }
 
+// addDayCards appends the given amount of each card to the deck.
+// Cards are added in FarmItemType order rather than map order, so that a
+// seeded shuffle always deals the same deck.
+func addDayCards(deck Stack, amounts map[FarmItemType]int) Stack {
+	for farmItem := FarmItemType(0); farmItem < NUM_FARM_ITEMS; farmItem++ {
+		for range amounts[farmItem] {
+			deck = append(deck, farmItem)
+		}
+	}
+	return deck
+}
+
 // createNightDeck creates a shuffled deck of night cards containing all zombies and events.
-func createNightDeck() []NightCard {
+// Expansion zombies are only included when the zombie expansion is enabled.
+func createNightDeck(options GameOptions, rng *rand.Rand) []NightCard {
 	var deck = make([]NightCard, 0)
-	for zKey, zombie := range ZombieChickens {
+	// Sorted keys keep the deck the same for a given seed
+	for _, zKey := range slices.Sorted(maps.Keys(ZombieChickens)) {
+		zombie := ZombieChickens[zKey]
+		if zombie.Expansion && !options.ZombieExpansion {
+			continue
+		}
 		for range zombie.NumInDeck {
 			deck = append(deck, NightCard{
 				ZombieKey: zKey,
@@ -37,11 +150,15 @@ func createNightDeck() []NightCard {
 		})
 	}
 
-	shuffle(deck)
+	shuffle(rng, deck)
 
 	return deck
 }
 
+// MaxPlayers is the most players a game supports. Games with more than 4
+// players play with a second set of day and night cards.
+const MaxPlayers = 8
+
 // StartingLivesLookup maps player count to starting lives per player.
 // Fewer players get more lives to balance difficulty.
 var StartingLivesLookup = map[int]int{
@@ -49,46 +166,83 @@ var StartingLivesLookup = map[int]int{
 	2: 5,
 	3: 4,
 	4: 4,
+	5: 4,
+	6: 3,
+	7: 3,
+	8: 3,
 }
 
-// dealPublicDayCards draws two cards from the day deck to be the public cards.
+// dealPublicDayCards draws Rules.PublicCards cards from the day deck to be the
+// public cards.
 func (g *gameState) dealPublicDayCards() {
-	g.PublicDayCards = [2]FarmItemType{g.nextDayCard(), g.nextDayCard()}
+	g.PublicDayCards = make(PublicDayCards, g.Options.Rules.PublicCards)
+	for i := range g.PublicDayCards {
+		g.PublicDayCards[i] = g.nextDayCard()
+	}
 }
 
-// CreateNewGame initializes a new game with the given player names.
+// CreateNewGame initializes a new base game with the given player names.
 // Returns a GameView for interacting with the game, or an error if the
-// player count is invalid (must be 1-4 players).
+// player count is invalid (must be 1-MaxPlayers players).
 //
 // The game is initialized with:
 //   - Shuffled day and night decks
-//   - Each player with starting lives (based on player count) and 5 cards
+//   - Each player with starting lives (based on player count) and Rules.HandSize cards
 //   - Two public day cards face-up for drawing
 //   - Turn set to Morning, ready for the first player's turn
 //
+// GameOptions.Rules can change these numbers.
+//
 // After creation, call ContinueDay() on the returned GameView to begin the game.
 func CreateNewGame(playerNames ...string) (GameView, error) {
+	return CreateNewGameWithOptions(GameOptions{}, playerNames...)
+}
+
+// CreateNewGameWithOptions initializes a new game like CreateNewGame, using
+// the given options to enable optional rules such as expansions.
+func CreateNewGameWithOptions(options GameOptions, playerNames ...string) (GameView, error) {
 	if len(playerNames) == 0 {
 		return GameView{}, fmt.Errorf("must provide at least 1 player")
+	} else if len(playerNames) > MaxPlayers {
+		return GameView{}, fmt.Errorf("must provide max %d player names", MaxPlayers)
+	}
+	if err := validateTeams(options, len(playerNames)); err != nil {
+		return GameView{}, err
+	}
+	if err := options.Rules.Validate(); err != nil {
+		return GameView{}, err
 	}
-	// } else if len(playerNames) > 4 {
-	// return GameView{}, fmt.Errorf("must provide max 4 player names")
-	// }
+	options.Rules = options.Rules.WithDefaults()
 
+	seed := options.Seed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
 	var (
+		rng       = rand.New(rand.NewSource(seed))
 		dayDeck   = Stack{}
 		nightDeck = []NightCard{}
 	)
 
+	// One full set of cards per 4 players, shuffled together
 	decksNeeded := (len(playerNames) + 3) / 4
 	for range decksNeeded {
-		dayDeck = append(dayDeck, createDayDeck()...)
-		nightDeck = append(nightDeck, createNightDeck()...)
+		dayDeck = append(dayDeck, createDayDeck(options, rng)...)
+		nightDeck = append(nightDeck, createNightDeck(options, rng)...)
+	}
+	if decksNeeded > 1 {
+		shuffle(rng, dayDeck)
+		shuffle(rng, nightDeck)
 	}
 
 	var g = &gameState{
+		Options:             options,
+		Seed:                seed,
+		rng:                 rng,
 		DayDeck:             dayDeck,
 		NightDeck:           nightDeck,
+		FullDayDeck:         append(Stack{}, dayDeck...),
+		FullNightDeck:       append(NightCards{}, nightDeck...),
 		Turn:                Morning,
 		StageInTurn:         OptionalDiscard,
 		CurrentPlayerIdx:    0, //redundant
@@ -102,6 +256,10 @@ func CreateNewGame(playerNames ...string) (GameView, error) {
 	for i, name := range playerNames {
 		g.Players = append(g.Players, createPlayer(g, name, len(playerNames), i))
 	}
+	if options.Roles {
+		g.dealRoles()
+	}
+	g.startSetup()
 	if err := g.assertNewGame(); err != nil {
 		return GameView{}, err
 	}
@@ -139,27 +297,45 @@ func (g *gameState) DebugEventsOnTop() {
 	// Blood Moon first, Winter Solstice second, then other events, then zombies
 	g.NightDeck = append([]NightCard{bloodMoon, winterSolstice}, otherEvents...)
 	g.NightDeck = append(g.NightDeck, zombies...)
+	g.DebugDeck = true
 }
 
-// createPlayer creates a new player with starting lives and a 5-card hand.
+// startingLives returns the lives each player starts with: Rules.StartingLives
+// if set, otherwise the StartingLivesLookup entry for the player count.
+// Teammates put their lives into one shared pool, so each of them starts with
+// the whole pool.
+func startingLives(rules Rules, numPlayers int, teamSize int) int {
+	if rules.StartingLives > 0 {
+		return rules.StartingLives * teamSize
+	}
+	return StartingLivesLookup[numPlayers] * teamSize
+}
+
+// createPlayer creates a new player with starting lives and a full hand.
 func createPlayer(g *gameState, name string, numPlayers int, playerIdx int) *Player {
+	teams := seatTeams(g.Options, numPlayers)
 	return &Player{
+		ID:    PlayerID(playerIdx + 1),
 		Name:  name,
-		Lives: StartingLivesLookup[numPlayers],
+		Team:  teams[playerIdx],
+		Lives: startingLives(g.Options.Rules, numPlayers, countTeam(teams, teams[playerIdx])),
 		Farm: &Farm{
 			Stacks:     Stacks{},
 			NightCards: NightCards{},
 		},
-		Hand: Hand{
-			HandItem{FarmItemType: g.nextDayCard()},
-			HandItem{FarmItemType: g.nextDayCard()},
-			HandItem{FarmItemType: g.nextDayCard()},
-			HandItem{FarmItemType: g.nextDayCard()},
-			HandItem{FarmItemType: g.nextDayCard()},
-		},
+		Hand: g.dealHand(g.Options.Rules.HandSize),
 		PlayChoices: PlayerPlayChoices{
 			AutoloadShotgun:  true,
 			AutoBuildHayWall: true,
 		},
 	}
 }
+
+// dealHand deals a hand of the given size from the day deck.
+func (g *gameState) dealHand(size int) Hand {
+	hand := make(Hand, size)
+	for i := range hand {
+		hand[i] = HandItem{FarmItemType: g.nextDayCard()}
+	}
+	return hand
+}
@@ -0,0 +1,43 @@
+package zcgame
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPlayerCounts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	daySet := len(createDayDeck(GameOptions{}, rng))
+	nightSet := len(createNightDeck(GameOptions{}, rng))
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+
+	for n := 1; n <= MaxPlayers; n++ {
+		view, err := CreateNewGameWithOptions(GameOptions{}, names[:n]...)
+		if err != nil {
+			t.Fatalf("%d players: failed to create game: %v", n, err)
+		}
+		g := view.game
+		sets := 1
+		if n > 4 {
+			sets = 2
+		}
+		if len(g.FullDayDeck) != sets*daySet || len(g.FullNightDeck) != sets*nightSet {
+			t.Errorf("%d players: expected %d card sets, got %d day and %d night cards", n, sets, len(g.FullDayDeck), len(g.FullNightDeck))
+		}
+		for _, player := range g.Players {
+			if player.Lives != StartingLivesLookup[n] {
+				t.Errorf("%d players: expected %d lives, got %d", n, StartingLivesLookup[n], player.Lives)
+			}
+		}
+	}
+	if _, err := CreateNewGameWithOptions(GameOptions{}, names...); err == nil {
+		t.Errorf("expected an error for %d players", len(names))
+	}
+}
+
+func TestEightPlayerGames(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for seed := range int64(10) {
+		simulateGame(t, GameOptions{Seed: seed + 1}, names...)
+	}
+}
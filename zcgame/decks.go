@@ -1,10 +1,19 @@
 package zcgame
 
+import "strconv"
+
 // nextDayCard draws and returns the top card from the day deck.
 // If the deck is empty or has only one card, it refills from the discard pile.
+// If there is nothing left to draw, the DeckExhaustion option decides what happens;
+// NUM_FARM_ITEMS (an empty hand slot) is returned when no card is drawn.
 func (g *gameState) nextDayCard() FarmItemType {
+	// A drawn card can't be taken back
+	g.clearUndo()
 	if len(g.DayDeck) == 0 {
 		g.refillDayCards()
+		if len(g.DayDeck) == 0 && !g.restockDayDeck() {
+			return NUM_FARM_ITEMS
+		}
 	} else if len(g.DayDeck) == 1 {
 		card := g.DayDeck[0]
 		g.DayDeck = g.DayDeck[:0] // clear
@@ -18,43 +27,193 @@ func (g *gameState) nextDayCard() FarmItemType {
 	return card
 }
 
+// restockDayDeck applies the DeckExhaustion option once the day deck and its
+// discard pile are both empty. Returns false if no card can be drawn.
+func (g *gameState) restockDayDeck() bool {
+	switch g.Options.DeckExhaustion {
+	case DeckExhaustionReshuffle:
+		g.DayDeck = g.dayCardsOutOfPlay()
+		shuffle(g.rng, g.DayDeck)
+		return len(g.DayDeck) > 0
+	case DeckExhaustionEndGame:
+		g.endGame(GameEndDeckExhausted)
+	}
+	return false
+}
+
+// dayCardsOutOfPlay returns the cards of FullDayDeck that aren't in the deck,
+// the discard pile, a hand, a farm, the face-up cards or the draft pool.
+func (g *gameState) dayCardsOutOfPlay() Stack {
+	left := map[FarmItemType]int{}
+	for _, item := range g.FullDayDeck {
+		left[item]++
+	}
+	take := func(item FarmItemType) {
+		if item == WornPitchfork {
+			item = Pitchfork
+		}
+		left[item]--
+	}
+	for _, item := range g.DayDeck {
+		take(item)
+	}
+	for item, count := range g.DiscardedDayCards {
+		left[item] -= count
+	}
+	for _, item := range g.PublicDayCards {
+		take(item)
+	}
+	for _, item := range g.DraftPool {
+		take(item)
+	}
+	for _, player := range g.Players {
+		for _, card := range player.Hand {
+			take(card.FarmItemType)
+		}
+		for _, stack := range player.Farm.Stacks {
+			for _, item := range stack {
+				take(item)
+			}
+		}
+	}
+
+	// Walk FullDayDeck rather than the map so a seed always restocks the same deck
+	cards := Stack{}
+	for _, item := range g.FullDayDeck {
+		if left[item] > 0 {
+			cards = append(cards, item)
+			left[item]--
+		}
+	}
+	return cards
+}
+
 // discardDayCard adds a card to the day discard pile.
+// A WornPitchfork goes back to the pile as a fresh Pitchfork.
 func (g *gameState) discardDayCard(item FarmItemType) {
+	if item == WornPitchfork {
+		item = Pitchfork
+	}
 	g.DiscardedDayCards[item]++
 }
 
 // refillDayCards moves all discarded day cards back into the deck and shuffles.
 func (g *gameState) refillDayCards() {
-	for farmItem, amount := range g.DiscardedDayCards {
-		for range amount {
-			g.DayDeck = append(g.DayDeck, farmItem)
-		}
-	}
+	g.DayDeck = addDayCards(g.DayDeck, g.DiscardedDayCards)
 
-	shuffle(g.DayDeck)
+	shuffle(g.rng, g.DayDeck)
 
 	g.DiscardedDayCards = map[FarmItemType]int{} // clear
 }
 
 // nextNightCard draws and returns the top card from the night deck.
 // If the deck is empty, it refills from the discard pile and shuffles.
-func (g *gameState) nextNightCard() NightCard {
+// If there is nothing left to draw, the DeckExhaustion option decides what
+// happens; false is returned when no card is drawn.
+func (g *gameState) nextNightCard() (NightCard, bool) {
 	if len(g.NightDeck) == 0 {
 		g.NightDeck = g.DiscardedNightCards
-		shuffle(g.NightDeck)
+		shuffle(g.rng, g.NightDeck)
 		g.DiscardedNightCards = make([]NightCard, 0)
+		if len(g.NightDeck) == 0 && !g.restockNightDeck() {
+			return NightCard{}, false
+		}
 	}
 	nightCard := g.NightDeck[0]
 	g.NightDeck = g.NightDeck[1:]
 
-	return nightCard
+	return nightCard, true
+}
+
+// restockNightDeck applies the DeckExhaustion option once the night deck and
+// its discard pile are both empty. Returns false if no card can be drawn.
+func (g *gameState) restockNightDeck() bool {
+	switch g.Options.DeckExhaustion {
+	case DeckExhaustionReshuffle:
+		g.NightDeck = g.nightCardsOutOfPlay()
+		shuffle(g.rng, g.NightDeck)
+		return len(g.NightDeck) > 0
+	case DeckExhaustionEndGame:
+		g.endGame(GameEndDeckExhausted)
+	}
+	return false
+}
+
+// nightCardsOutOfPlay returns the cards of FullNightDeck that aren't in the
+// deck, the discard pile or a farm's night cards, such as zombies dropped after
+// they got through.
+func (g *gameState) nightCardsOutOfPlay() NightCards {
+	// Events are told apart by name, zombies by key
+	key := func(card NightCard) string {
+		if card.IsEvent() {
+			return card.Event.Name
+		}
+		return strconv.Itoa(card.ZombieKey)
+	}
+	left := map[string]int{}
+	for _, card := range g.FullNightDeck {
+		left[key(card)]++
+	}
+	for _, pile := range []NightCards{g.NightDeck, g.DiscardedNightCards} {
+		for _, card := range pile {
+			left[key(card)]--
+		}
+	}
+	for _, player := range g.Players {
+		for _, card := range player.Farm.NightCards {
+			left[key(card)]--
+		}
+	}
+
+	cards := NightCards{}
+	for _, card := range g.FullNightDeck {
+		if left[key(card)] > 0 {
+			cards = append(cards, card)
+			left[key(card)]--
+		}
+	}
+	return cards
+}
+
+// dealNightCards gives the player n more night cards, or as many as can be drawn.
+func (g *gameState) dealNightCards(player *Player, n int) {
+	for range n {
+		card, ok := g.nextNightCard()
+		if !ok {
+			return
+		}
+		player.Farm.NightCards = append(player.Farm.NightCards, card)
+	}
+}
+
+// nextZombieCard draws night cards until a zombie turns up, putting any events
+// it passes back on the bottom of the night deck. Returns false if the night deck
+// and discard pile hold no zombies.
+func (g *gameState) nextZombieCard() (NightCard, bool) {
+	for range len(g.NightDeck) + len(g.DiscardedNightCards) {
+		card, ok := g.nextNightCard()
+		if !ok {
+			break
+		}
+		if card.IsZombie() {
+			return card, true
+		}
+		g.NightDeck = append(g.NightDeck, card)
+	}
+	return NightCard{}, false
 }
 
 // discardNightCard adds a night card to the discard pile.
+// Any attacks resolved from the card are forgotten so it comes back fresh.
+// Bosses are dealt on boss nights only, so they never go to the discard pile.
 func (g *gameState) discardNightCard(n NightCard) {
+	if n.IsZombie() && ZombieChickens[n.ZombieKey].IsBoss() {
+		return
+	}
 	if g.DiscardedNightCards == nil {
 		g.DiscardedNightCards = make([]NightCard, 0)
 	}
 
+	n.Resolved = 0
 	g.DiscardedNightCards = append(g.DiscardedNightCards, n)
 }
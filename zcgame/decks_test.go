@@ -0,0 +1,166 @@
+package zcgame
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// emptyDecks takes every card out of the game's decks and discard piles.
+func emptyDecks(g *gameState) {
+	g.DayDeck = Stack{}
+	g.DiscardedDayCards = map[FarmItemType]int{}
+	g.NightDeck = NightCards{}
+	g.DiscardedNightCards = NightCards{}
+}
+
+// dayCardCount counts the day cards in the deck, the discard pile, hands, farms,
+// the face-up cards and the draft pool.
+func dayCardCount(g *gameState) int {
+	count := len(g.DayDeck) + len(g.DraftPool)
+	for _, n := range g.DiscardedDayCards {
+		count += n
+	}
+	cards := slices.Clone(g.PublicDayCards)
+	for _, player := range g.Players {
+		for _, card := range player.Hand {
+			cards = append(cards, card.FarmItemType)
+		}
+		for _, stack := range player.Farm.Stacks {
+			count += len(stack)
+		}
+	}
+	for _, item := range cards {
+		if item != NUM_FARM_ITEMS {
+			count++
+		}
+	}
+	return count
+}
+
+// nightCardCount counts the night cards in the deck, the discard pile and on farms.
+func nightCardCount(g *gameState) int {
+	count := len(g.NightDeck) + len(g.DiscardedNightCards)
+	for _, player := range g.Players {
+		count += len(player.Farm.NightCards)
+	}
+	return count
+}
+
+func TestExhaustedDayDeck(t *testing.T) {
+	for rule := DeckExhaustionReshuffle; rule <= DeckExhaustionEndGame; rule++ {
+		g := newTestGame(t, GameOptions{DeckExhaustion: rule}, "a", "b")
+		emptyDecks(g)
+
+		card := g.nextDayCard()
+		switch rule {
+		case DeckExhaustionReshuffle:
+			// The emptied deck's cards left play, so they come back
+			if card == NUM_FARM_ITEMS || dayCardCount(g)+1 != len(g.FullDayDeck) {
+				t.Errorf("%s: expected a card from the restocked deck, got %s with %d cards in the game", rule, card, dayCardCount(g)+1)
+			}
+		default:
+			if card != NUM_FARM_ITEMS {
+				t.Errorf("%s: expected an empty hand slot, got %s", rule, card)
+			}
+		}
+		if ended := g.EndReason == GameEndDeckExhausted; ended != (rule == DeckExhaustionEndGame) {
+			t.Errorf("%s: expected the game to end %v, got %s", rule, rule == DeckExhaustionEndGame, g.EndReason)
+		}
+	}
+}
+
+func TestExhaustedNightDeck(t *testing.T) {
+	for rule := DeckExhaustionReshuffle; rule <= DeckExhaustionEndGame; rule++ {
+		g := newTestGame(t, GameOptions{DeckExhaustion: rule}, "a", "b")
+		emptyDecks(g)
+
+		_, ok := g.nextNightCard()
+		if ok != (rule == DeckExhaustionReshuffle) {
+			t.Errorf("%s: expected a card to be drawn %v", rule, rule == DeckExhaustionReshuffle)
+		}
+		if ok && nightCardCount(g)+1 != len(g.FullNightDeck) {
+			t.Errorf("%s: expected the night deck restocked, got %d cards in the game", rule, nightCardCount(g)+1)
+		}
+		if ended := g.EndReason == GameEndDeckExhausted; ended != (rule == DeckExhaustionEndGame) {
+			t.Errorf("%s: expected the game to end %v, got %s", rule, rule == DeckExhaustionEndGame, g.EndReason)
+		}
+	}
+}
+
+// TestRestockKeepsCardCount checks a reshuffle only brings back cards that
+// left play, so the game never holds more cards than it started with.
+func TestRestockKeepsCardCount(t *testing.T) {
+	g := newTestGame(t, GameOptions{}, "a", "b")
+	// Every card left to draw goes onto a's farm
+	farm := g.Players[0].Farm
+	farm.Stacks = Stacks{addDayCards(slices.Clone(g.DayDeck), g.DiscardedDayCards)}
+	farm.NightCards = append(slices.Clone(g.NightDeck), g.DiscardedNightCards...)
+	g.DayDeck, g.DiscardedDayCards = Stack{}, map[FarmItemType]int{}
+	g.NightDeck, g.DiscardedNightCards = NightCards{}, NightCards{}
+	days, nights := dayCardCount(g), nightCardCount(g)
+	if days != len(g.FullDayDeck) || nights != len(g.FullNightDeck) {
+		t.Fatalf("expected %d day and %d night cards, got %d and %d", len(g.FullDayDeck), len(g.FullNightDeck), days, nights)
+	}
+
+	if card := g.nextDayCard(); card != NUM_FARM_ITEMS || dayCardCount(g) != days {
+		t.Errorf("expected no day card with every card in play, got %s and %d cards", card, dayCardCount(g))
+	}
+	if _, ok := g.nextNightCard(); ok || nightCardCount(g) != nights {
+		t.Errorf("expected no night card with every card in play, got %d cards", nightCardCount(g))
+	}
+
+	// A card that leaves play is the only one that comes back
+	lost := farm.Stacks[0][0]
+	farm.Stacks[0] = farm.Stacks[0][1:]
+	if card := g.nextDayCard(); card != lost || dayCardCount(g)+1 != days {
+		t.Errorf("expected the lost %s back, got %s and %d cards", lost, card, dayCardCount(g)+1)
+	}
+	night := farm.NightCards[0]
+	farm.NightCards = farm.NightCards[1:]
+	card, ok := g.nextNightCard()
+	if !ok || card.ZombieKey != night.ZombieKey || card.Event.Name != night.Event.Name || nightCardCount(g)+1 != nights {
+		t.Errorf("expected the lost night card back, got %v and %d cards", card, nightCardCount(g)+1)
+	}
+}
+
+// TestExhaustedDeckGames plays games that run both decks dry, which used to
+// panic once a deck and its discard pile were both empty. With
+// DeckExhaustionSkip the zombies can run out for good, so only the first 20
+// nights are played.
+func TestExhaustedDeckGames(t *testing.T) {
+	for rule := DeckExhaustionReshuffle; rule <= DeckExhaustionEndGame; rule++ {
+		for seed := range int64(10) {
+			view, err := CreateNewGameWithOptions(GameOptions{DeckExhaustion: rule, Seed: seed + 1}, "a", "b", "c")
+			if err != nil {
+				t.Fatalf("failed to create game: %v", err)
+			}
+			g := view.game
+			// A few cards, with zombies only at night so lives keep being lost
+			zombies := NightCards{}
+			for _, card := range g.NightDeck {
+				if card.IsZombie() {
+					zombies = append(zombies, card)
+				}
+			}
+			g.DayDeck = g.DayDeck[:4]
+			g.NightDeck = zombies[:3]
+
+			rng := rand.New(rand.NewSource(g.Seed))
+			cont, input := g.ContinueDay()
+			for steps := 0; (cont || input != nil) && g.NightNum <= 20; steps++ {
+				if steps > 100000 {
+					t.Fatalf("%s: game did not end", rule)
+				}
+				if input == nil {
+					cont, input = g.ContinueDay()
+					continue
+				}
+				cont, input = g.ContinueAfterInput(randomChoice(rng, input))
+			}
+			if rule == DeckExhaustionEndGame && g.EndReason != GameEndDeckExhausted {
+				t.Errorf("%s: expected the game to end when a deck ran out, got %s", rule, g.EndReason)
+			}
+		}
+	}
+}
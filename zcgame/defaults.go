@@ -0,0 +1,63 @@
+package zcgame
+
+// Default Choices
+//
+// Every prompt handed out carries a DefaultChoice: the answer to give for a
+// player who doesn't answer, such as one who has walked away from a web game
+// with a turn timer. Defaults are cautious - skip what can be skipped, draw
+// from the deck, defend with the cheapest stack, keep what was dealt - so a
+// timed-out player stops holding up the table without throwing their farm
+// away. The engine never submits a default itself; that is up to the frontend.
+
+import "slices"
+
+// attachDefault sets the input's DefaultChoice. Returns the input for chaining.
+func (g *gameState) attachDefault(input *PlayerInputNeeded) *PlayerInputNeeded {
+	if input == nil || len(input.ValidChoices) == 0 {
+		return input
+	}
+	input.DefaultChoice = g.defaultChoice(input)
+	return input
+}
+
+// defaultChoice returns the choice a player who doesn't answer the input makes.
+// It is always one of the input's ValidChoices.
+func (g *gameState) defaultChoice(input *PlayerInputNeeded) int {
+	prefer := func(choice int) int {
+		if slices.Contains(input.ValidChoices, choice) {
+			return choice
+		}
+		return input.ValidChoices[0]
+	}
+
+	switch input.Context {
+	case InputContextDiscard, InputContextGive, InputContextLend, InputContextConfirm:
+		// Skip the optional discard or gift, refuse to lend, continue
+		return prefer(0)
+	case InputContextDraw:
+		return prefer(DrawChoiceDeck)
+	case InputContextMulligan:
+		return prefer(MulliganChoiceKeep)
+	case InputContextShield:
+		// Spending the Shield saves the whole stack
+		return prefer(1)
+	case InputContextChoosePlayer:
+		// Keep a zombie rather than bait it onto a neighbor
+		return prefer(0)
+	case InputContextPlayCard:
+		// Start a new stack rather than cancel or guess at a stack
+		return prefer(0)
+	case InputContextDefense:
+		// A boss destroys any stack thrown at it, but takes a life for every
+		// hit left, so the smallest stack is still the cheaper loss
+		fight := g.NightSubStage == NightSubStageChooseDefense || g.NightSubStage == NightSubStageBossFight
+		if g.Turn == Night && fight {
+			if choice, ok := g.cheapestDefense(input, false); ok {
+				return choice
+			}
+		}
+		return prefer(-1)
+	}
+	// Play the first card, pick the first player, card or stack
+	return input.ValidChoices[0]
+}
@@ -0,0 +1,55 @@
+package zcgame
+
+import "testing"
+
+func TestDefaultChoices(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{Seed: 9}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	_, input := view.ContinueDay()
+	if input.Context != InputContextDiscard || input.DefaultChoice != 0 {
+		t.Fatalf("expected the optional discard to default to skipping, got %d", input.DefaultChoice)
+	}
+	for input != nil && input.Context != InputContextDraw {
+		_, input = view.ContinueAfterInput(input.DefaultChoice)
+	}
+	if input == nil || input.DefaultChoice != DrawChoiceDeck {
+		t.Fatalf("expected the draw to default to the deck, got %v", input)
+	}
+
+	g := newTestGame(t, GameOptions{InteractionCards: true}, "a", "b", "c")
+	g.Players[0].Farm.Stacks = Stacks{{Bait}}
+	input = startNight(t, g, NightCards{{ZombieKey: 8}})
+	if input.Context != InputContextChoosePlayer || input.DefaultChoice != 0 {
+		t.Fatalf("expected the Bait to default to keeping the zombie, got %d of %v", input.DefaultChoice, input.ValidChoices)
+	}
+
+	// A boss fight defaults to the smallest stack rather than a life per hit
+	g = newTestGame(t, GameOptions{BossEvery: 1}, "a", "b")
+	g.Players[0].Farm.Stacks = Stacks{{Shotgun, Ammo, Ammo}, {Shotgun, Ammo}}
+	startNight(t, g, NightCards{{ZombieKey: 101}})
+	_, input = g.ContinueAfterInput(0)
+	if g.NightSubStage != NightSubStageBossFight || input.DefaultChoice != 2 {
+		t.Fatalf("expected the boss fight to default to the smaller stack, got %d of %v", input.DefaultChoice, input.ValidChoices)
+	}
+
+	// A table of players who never answer still finishes the game
+	for _, options := range []GameOptions{{}, {Mode: ModeCoop}, {BossEvery: 2, SimultaneousDiscards: true}} {
+		view, err := CreateNewGameWithOptions(options, "p1", "p2", "p3")
+		if err != nil {
+			t.Fatalf("failed to create game: %v", err)
+		}
+		cont, input := view.ContinueDay()
+		for steps := 0; cont || input != nil; steps++ {
+			if steps > 100000 {
+				t.Fatalf("%s game with defaults did not end", options.Mode)
+			}
+			if input == nil {
+				cont, input = view.ContinueDay()
+				continue
+			}
+			cont, input = view.ContinueAfterInput(input.DefaultChoice)
+		}
+	}
+}
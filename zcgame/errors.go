@@ -12,8 +12,8 @@ func (g *gameState) assertNewGame() error {
 		errs = append(errs, fmt.Errorf("Players: slice is nil"))
 	} else if len(g.Players) == 0 {
 		errs = append(errs, fmt.Errorf("Players: must have at least 1 player"))
-	} else if len(g.Players) > 4 {
-		errs = append(errs, fmt.Errorf("Players: cannot have more than 4 players, got %d", len(g.Players)))
+	} else if len(g.Players) > MaxPlayers {
+		errs = append(errs, fmt.Errorf("Players: cannot have more than %d players, got %d", MaxPlayers, len(g.Players)))
 	} else {
 		// Validate each player
 		for i, player := range g.Players {
@@ -27,8 +27,18 @@ func (g *gameState) assertNewGame() error {
 				errs = append(errs, fmt.Errorf("Players[%d]: name is empty", i))
 			}
 
+			// Validate player ID
+			if player.ID == 0 {
+				errs = append(errs, fmt.Errorf("Players[%d]: ID is not set", i))
+			}
+			for j := range i {
+				if g.Players[j] != nil && g.Players[j].ID == player.ID {
+					errs = append(errs, fmt.Errorf("Players[%d]: ID %d is already used by Players[%d]", i, player.ID, j))
+				}
+			}
+
 			// Validate player lives
-			expectedLives := StartingLivesLookup[len(g.Players)]
+			expectedLives := startingLives(g.Options.Rules, len(g.Players), len(g.teamOf(player)))
 			if player.Lives != expectedLives {
 				errs = append(errs, fmt.Errorf("Players[%d]: lives should be %d for %d players, got %d", i, expectedLives, len(g.Players), player.Lives))
 			}
@@ -37,11 +47,15 @@ func (g *gameState) assertNewGame() error {
 			if player.Farm == nil {
 				errs = append(errs, fmt.Errorf("Players[%d]: farm is nil", i))
 			} else {
-				// Farm should be empty for new game
+				// Farm should be empty for new game, apart from a Rancher's Hay Wall
+				expectedStacks := 0
+				if player.Role == Rancher {
+					expectedStacks = 1
+				}
 				if player.Farm.Stacks == nil {
 					errs = append(errs, fmt.Errorf("Players[%d]: farm stacks is nil", i))
-				} else if len(player.Farm.Stacks) > 0 {
-					errs = append(errs, fmt.Errorf("Players[%d]: farm should be empty for new game, has %d stacks", i, len(player.Farm.Stacks)))
+				} else if len(player.Farm.Stacks) != expectedStacks {
+					errs = append(errs, fmt.Errorf("Players[%d]: farm should have %d stacks for new game, has %d stacks", i, expectedStacks, len(player.Farm.Stacks)))
 				}
 
 				// Night cards should be empty for new game
@@ -52,18 +66,33 @@ func (g *gameState) assertNewGame() error {
 				}
 			}
 
-			// Validate hand has exactly 5 cards
+			// Validate role
+			if player.Role >= NUM_ROLES || (player.Role == RoleNone) == g.Options.Roles {
+				errs = append(errs, fmt.Errorf("Players[%d]: invalid role %d", i, player.Role))
+			}
+
+			// Validate hand has a full hand of cards, or none before a draft
+			if len(player.Hand) != g.Options.Rules.HandSize {
+				errs = append(errs, fmt.Errorf("Players[%d]: hand should have %d slots, has %d", i, g.Options.Rules.HandSize, len(player.Hand)))
+			}
+			expectedHandCards := len(player.Hand)
+			if g.Options.Setup == SetupDraft {
+				expectedHandCards = 0
+			}
 			validHandCards := 0
 			for j, handItem := range player.Hand {
-				// Check if FarmItemType is valid
+				// Check if FarmItemType is valid; drafted hands start empty
+				if handItem.FarmItemType == NUM_FARM_ITEMS && expectedHandCards == 0 {
+					continue
+				}
 				if handItem.FarmItemType >= NUM_FARM_ITEMS {
 					errs = append(errs, fmt.Errorf("Players[%d].Hand[%d]: invalid FarmItemType %d", i, j, handItem.FarmItemType))
 				} else {
 					validHandCards++
 				}
 			}
-			if validHandCards != 5 {
-				errs = append(errs, fmt.Errorf("Players[%d]: hand should have exactly 5 cards, got %d", i, validHandCards))
+			if validHandCards != expectedHandCards {
+				errs = append(errs, fmt.Errorf("Players[%d]: hand should have exactly %d cards, got %d", i, expectedHandCards, validHandCards))
 			}
 		}
 	}
@@ -74,12 +103,12 @@ func (g *gameState) assertNewGame() error {
 	}
 
 	// Validate StageInTurn
-	if g.StageInTurn != OptionalDiscard && g.StageInTurn != Play2Cards && g.StageInTurn != Draw2Cards && g.StageInTurn != Nighttime {
+	if g.StageInTurn != OptionalDiscard && g.StageInTurn != Play2Cards && g.StageInTurn != Draw2Cards && g.StageInTurn != Nighttime && g.StageInTurn != ShareCards && g.StageInTurn != ChooseHand {
 		errs = append(errs, fmt.Errorf("StageInTurn: invalid value %d", g.StageInTurn))
 	}
 
 	// Validate Turn
-	if g.Turn != Morning && g.Turn != Afternoon && g.Turn != Night && g.Turn != Day {
+	if g.Turn != Morning && g.Turn != Afternoon && g.Turn != Night && g.Turn != Day && g.Turn != Setup {
 		errs = append(errs, fmt.Errorf("Turn: invalid turn value %d", g.Turn))
 	}
 
@@ -98,6 +127,9 @@ func (g *gameState) assertNewGame() error {
 	}
 
 	// Validate PublicDayCards
+	if len(g.PublicDayCards) != g.Options.Rules.PublicCards {
+		errs = append(errs, fmt.Errorf("PublicDayCards: should have %d cards, has %d", g.Options.Rules.PublicCards, len(g.PublicDayCards)))
+	}
 	for i, card := range g.PublicDayCards {
 		if card >= NUM_FARM_ITEMS && card != 0 {
 			errs = append(errs, fmt.Errorf("PublicDayCards[%d]: invalid FarmItemType %d", i, card))
@@ -139,6 +171,32 @@ func (g *gameState) assertNewGame() error {
 		errs = append(errs, fmt.Errorf("NightNum: should be 1 for new game, got %d", g.NightNum))
 	}
 
+	// Validate Options
+	if g.Options.BossEvery < 0 {
+		errs = append(errs, fmt.Errorf("Options.BossEvery: cannot be negative, got %d", g.Options.BossEvery))
+	}
+	if g.Options.DeckExhaustion > DeckExhaustionEndGame {
+		errs = append(errs, fmt.Errorf("Options.DeckExhaustion: invalid rule %d", g.Options.DeckExhaustion))
+	}
+	if g.Options.MaxNights < 0 {
+		errs = append(errs, fmt.Errorf("Options.MaxNights: cannot be negative, got %d", g.Options.MaxNights))
+	}
+	if g.Options.Mode > ModeSolo {
+		errs = append(errs, fmt.Errorf("Options.Mode: invalid mode %d", g.Options.Mode))
+	}
+	if g.Options.Mode == ModeSolo && len(g.Players) != 1 {
+		errs = append(errs, fmt.Errorf("Options.Mode: solo games have exactly 1 player, got %d", len(g.Players)))
+	}
+	if g.Options.InteractionCards && (g.Options.Mode == ModeCoop || g.Options.Mode == ModeSolo) {
+		errs = append(errs, fmt.Errorf("Options.InteractionCards: co-op and solo games have no opponents to interact with"))
+	}
+	if g.Options.Setup > SetupDraft {
+		errs = append(errs, fmt.Errorf("Options.Setup: invalid setup %d", g.Options.Setup))
+	}
+	if g.Options.CoopNights < 0 {
+		errs = append(errs, fmt.Errorf("Options.CoopNights: cannot be negative, got %d", g.Options.CoopNights))
+	}
+
 	if len(errs) > 0 {
 		return &gameStateValidationError{Errors: errs}
 	}
@@ -197,6 +255,9 @@ func (e *StackValidationError) Error() string {
 //   - Ammo: any number alone, or with exactly 1 Shotgun
 //   - Flamethrower: 1 Flamethrower, optionally with 1 Fuel
 //   - Fuel: 1 Fuel alone, or with exactly 1 Flamethrower
+//   - Pitchfork, WornPitchfork, GuardDog, Bait: exactly 1, alone
+//   - ElectricFence: 1 Electric Fence, optionally with 1 Generator
+//   - Generator: 1 Generator alone, or with exactly 1 Electric Fence
 func (f *Farm) assertLegalStacks() error {
 	var errs []error
 
@@ -278,6 +339,16 @@ func validateStack(index int, counts map[FarmItemType]int) error {
 		return nil
 	}
 
+	// Pitchfork validation: must be exactly 1 Pitchfork (worn or not) alone
+	for _, single := range []FarmItemType{Pitchfork, WornPitchfork, GuardDog, Bait} {
+		if count, has := counts[single]; has {
+			if numTypes > 1 || count != 1 {
+				return fmt.Errorf("stack at index %d must contain exactly 1 %s alone but has %d items", index, single, totalItems)
+			}
+			return nil
+		}
+	}
+
 	// Shotgun/Ammo validation
 	shotgunCount, hasShotgun := counts[Shotgun]
 	_, hasAmmo := counts[Ammo]
@@ -349,6 +420,29 @@ func validateStack(index int, counts map[FarmItemType]int) error {
 		return nil
 	}
 
+	// ElectricFence/Generator validation
+	fenceCount, hasFence := counts[ElectricFence]
+	generatorCount, hasGenerator := counts[Generator]
+
+	if hasFence && hasGenerator {
+		// Must be exactly 1 Electric Fence with exactly 1 Generator, no other items
+		if numTypes > 2 {
+			return fmt.Errorf("stack at index %d contains Electric Fence/Generator with other illegal item types", index)
+		}
+		if fenceCount != 1 || generatorCount != 1 {
+			return fmt.Errorf("stack at index %d must have exactly 1 Electric Fence and 1 Generator but has %d Electric Fences and %d Generators", index, fenceCount, generatorCount)
+		}
+		return nil
+	}
+
+	if hasFence || hasGenerator {
+		// Electric Fence or Generator alone (exactly 1) is valid
+		if numTypes > 1 || fenceCount+generatorCount != 1 {
+			return fmt.Errorf("stack at index %d must have exactly 1 Electric Fence or Generator when unpaired", index)
+		}
+		return nil
+	}
+
 	// If we get here, there's an unknown combination
 	return fmt.Errorf("stack at index %d has unknown or invalid item combination", index)
 }
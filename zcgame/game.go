@@ -8,6 +8,8 @@ package zcgame
 // Public API:
 //   - ContinueDay() - Main entry point to advance game state
 //   - ContinueAfterInput() - Resume execution after player provides input
+//   - ContinueAfterInputs() - Answer a multi-select prompt with all its choices at once
+//   - ContinueAfterInputFrom() - Answer the prompt waiting on a given player (simultaneous discards)
 //   - CurrentPlayer() - Returns the player whose turn it is
 //   - HasLivingPlayers() - Checks if any players remain alive
 //
@@ -22,17 +24,44 @@ package zcgame
 // after player input is gathered. When a function returns *PlayerInputNeeded,
 // the current stage is saved so execution can resume at the correct point.
 
-import (
-	"fmt"
-)
+import "sort"
 
 // CurrentPlayer returns a pointer to the player whose turn it is.
 func (g *gameState) CurrentPlayer() *Player {
 	return g.Players[g.CurrentPlayerIdx]
 }
 
+// activeInputPlayerIdx returns the index of the player who answers the pending
+// prompt. This differs from CurrentPlayerIdx during event discards, where
+// every player discards, when a co-op neighbor is asked to lend a stack and
+// when a co-op teammate makes room for a gift.
+func (g *gameState) activeInputPlayerIdx() int {
+	// During event discards, calculate the actual player who needs to discard
+	if g.NightSubStage == NightSubStageEventDiscard {
+		return (g.EventDiscardStartIdx + g.EventDiscardPlayerIdx) % len(g.Players)
+	}
+	// In co-op a neighbor decides whether to lend a stack
+	if g.Turn == Night && g.NightSubStage == NightSubStageLendDefense {
+		return g.LenderIdx
+	}
+	if g.Turn == Day && g.DaySubStage == DaySubStageGiveRoom {
+		return g.GiveTargetIdx
+	}
+	return g.CurrentPlayerIdx
+}
+
+// stackChoicePlayerIdx returns the index of the player whose farm the pending
+// stack or item choices refer to; see GameView.StackChoicePlayerIdx.
+func (g *gameState) stackChoicePlayerIdx() int {
+	if (g.Turn == Morning || g.Turn == Afternoon) && g.DaySubStage == DaySubStageSabotageItem {
+		return g.ActionTargetIdx
+	}
+	return g.activeInputPlayerIdx()
+}
+
 // nextPlayer advances CurrentPlayerIdx to the next player, wrapping to 0 if needed.
 func (g *gameState) nextPlayer() {
+	g.clearUndo()
 	g.CurrentPlayerIdx++
 	if g.CurrentPlayerIdx >= len(g.Players) {
 		g.CurrentPlayerIdx = 0
@@ -40,7 +69,9 @@ func (g *gameState) nextPlayer() {
 }
 
 // eliminatePlayer discards all of a player's cards and removes them from the game.
-func (g *gameState) eliminatePlayer(player *Player) {
+// A copy of the player as they went out is kept in Eliminated, with the night
+// and cause of their elimination.
+func (g *gameState) eliminatePlayer(player *Player, cause string) {
 	// Find the player's index
 	playerIdx := -1
 	for i, p := range g.Players {
@@ -52,6 +83,9 @@ func (g *gameState) eliminatePlayer(player *Player) {
 	if playerIdx == -1 {
 		return
 	}
+	g.countCardsLeft(player)
+	player.Elimination = &Elimination{Night: g.NightNum, Cause: cause}
+	g.Eliminated = append(g.Eliminated, player.clone())
 
 	// Discard all farm cards
 	for _, stack := range player.Farm.Stacks {
@@ -67,7 +101,7 @@ func (g *gameState) eliminatePlayer(player *Player) {
 			g.discardDayCard(handItem.FarmItemType)
 		}
 	}
-	player.Hand = Hand{}
+	player.Hand = newHand(len(player.Hand))
 
 	// Discard remaining night cards
 	for _, nightCard := range player.Farm.NightCards {
@@ -100,52 +134,80 @@ func (g *gameState) doPlayerDayTurn() *PlayerInputNeeded {
 			return &PlayerInputNeeded{
 				Context:      InputContextDiscard,
 				RenderType:   RenderNormal,
-				Message:      "Discard a card to draw a replacement, or skip",
-				ValidChoices: []int{1, 2, 3, 4, 5, 0},
+				Text:         NewText(MsgDiscard),
+				ValidChoices: append(player.Hand.choices(), 0),
 			}
 
 		case DaySubStagePlay1:
 			g.StageInTurn = Play2Cards
-			return &PlayerInputNeeded{
-				Context:      InputContextPlay,
-				RenderType:   RenderNormal,
-				Message:      "Select first card to play to your farm",
-				ValidChoices: []int{1, 2, 3, 4, 5},
+			choices := g.playableChoices()
+			if len(choices) == 0 {
+				// Nothing to play when the day deck ran dry
+				g.DaySubStage = DaySubStageDraw
+				continue
 			}
+			return g.createPlayInput(g.playText(), choices)
 
 		case DaySubStagePlay1Stack:
 			// Player needs to choose which stack to place the pending card
 			return g.createStackSelectionInput()
 
 		case DaySubStagePlay2:
-			return &PlayerInputNeeded{
-				Context:      InputContextPlay,
-				RenderType:   RenderNormal,
-				Message:      "Select second card to play to your farm",
-				ValidChoices: []int{1, 2, 3, 4},
+			choices := g.playableChoices()
+			if len(choices) == 0 {
+				g.DaySubStage = DaySubStageDraw
+				continue
 			}
+			return g.createPlayInput(g.playText(), choices)
 
 		case DaySubStagePlay2Stack:
 			// Player needs to choose which stack to place the pending card
 			return g.createStackSelectionInput()
 
+		case DaySubStageActionTarget:
+			return g.createActionTargetInput()
+
+		case DaySubStageSabotageItem:
+			return g.createSabotageInput()
+
+		case DaySubStageTradeCard:
+			return g.createTradeInput()
+
+		case DaySubStageMoveCard:
+			return g.createMoveCardInput()
+
+		case DaySubStageMoveStack:
+			return g.createMoveStackInput()
+
 		case DaySubStageDraw:
 			g.StageInTurn = Draw2Cards
-			return &PlayerInputNeeded{
-				Context:      InputContextDraw,
-				RenderType:   RenderNormal,
-				Message:      "Choose how to draw cards",
-				ValidChoices: []int{1, 2},
-			}
+			return g.createDrawInput()
 
 		default:
 			// Turn complete - reset for next player
 			g.DaySubStage = DaySubStageOptionalDiscard
+			g.PlaysMade = 0
 			return nil
 		}
 	}
 }
 
+// createPlayInput creates the input request for choosing a card to play.
+// Moving a card on the farm is offered alongside the playable hand cards.
+func (g *gameState) createPlayInput(text Text, choices []int) *PlayerInputNeeded {
+	input := &PlayerInputNeeded{
+		Context:      InputContextPlay,
+		RenderType:   RenderNormal,
+		Text:         text,
+		ValidChoices: choices,
+	}
+	if g.Options.Reorganize && g.CurrentPlayer().Farm.canReorganize() {
+		input.ValidChoices = append(input.ValidChoices, PlayChoiceMove)
+		input.LabelTexts = map[int]Text{PlayChoiceMove: NewText(MsgPlayMove)}
+	}
+	return input
+}
+
 // createStackSelectionInput creates the input request for stack selection during card play
 func (g *gameState) createStackSelectionInput() *PlayerInputNeeded {
 	player := g.CurrentPlayer()
@@ -158,19 +220,22 @@ func (g *gameState) createStackSelectionInput() *PlayerInputNeeded {
 	}
 
 	// Convert 0-based stack indices to 1-based for display, add 0 for "new stack"
+	// and a cancel to pick another card
 	choices := make([]int, len(result.ValidStacks)+1)
 	choices[0] = 0 // new stack option
 	for i, idx := range result.ValidStacks {
 		choices[i+1] = idx + 1
 	}
+	choices = append(choices, StackChoiceCancel)
 
 	return &PlayerInputNeeded{
 		Context:      InputContextPlayCard,
 		RenderType:   RenderNormal,
-		Message:      fmt.Sprintf("Place %s: select a stack or start a new one", g.PendingCardItem),
+		Text:         NewText(MsgPlace, g.PendingCardItem),
 		ValidChoices: choices,
 		Item:         g.PendingCardItem,
 		ValidStacks:  result.ValidStacks,
+		LabelTexts:   map[int]Text{StackChoiceCancel: NewText(MsgPlaceCancel)},
 	}
 }
 
@@ -180,6 +245,10 @@ func (g *gameState) provideInput(choice int) *PlayerInputNeeded {
 	switch g.Turn {
 	case Morning, Afternoon:
 		return g.provideDayInput(choice)
+	case Day:
+		return g.provideCoopDayInput(choice)
+	case Setup:
+		return g.provideSetupInput(choice)
 	case Night:
 		return g.provideNightInput(choice)
 	}
@@ -201,6 +270,12 @@ func (g *gameState) provideDayInput(choice int) *PlayerInputNeeded {
 		return g.doPlayerDayTurn()
 
 	case DaySubStagePlay1:
+		if choice == PlayChoiceMove {
+			return g.startMove()
+		}
+		if player.Hand[choice-1].FarmItemType.IsAction() {
+			return g.startAction(choice)
+		}
 		g.PendingCardItem = player.Hand[choice-1].FarmItemType
 		result := player.Farm.PlayCard(g.PendingCardItem, player.PlayChoices)
 		if result != nil {
@@ -211,10 +286,15 @@ func (g *gameState) provideDayInput(choice int) *PlayerInputNeeded {
 		// Card played successfully
 		player.Hand[choice-1] = HandItem{FarmItemType: NUM_FARM_ITEMS}
 		player.Hand.Sort()
-		g.DaySubStage = DaySubStagePlay2
+		g.finishPlay()
 		return g.doPlayerDayTurn()
 
 	case DaySubStagePlay1Stack:
+		if choice == StackChoiceCancel {
+			// The card is still in hand until it is placed
+			g.DaySubStage = DaySubStagePlay1
+			return g.doPlayerDayTurn()
+		}
 		if choice == 0 {
 			// New stack
 			player.Farm.makeStackWith(g.PendingCardItem)
@@ -230,10 +310,16 @@ func (g *gameState) provideDayInput(choice int) *PlayerInputNeeded {
 			}
 		}
 		player.Hand.Sort()
-		g.DaySubStage = DaySubStagePlay2
+		g.finishPlay()
 		return g.doPlayerDayTurn()
 
 	case DaySubStagePlay2:
+		if choice == PlayChoiceMove {
+			return g.startMove()
+		}
+		if player.Hand[choice-1].FarmItemType.IsAction() {
+			return g.startAction(choice)
+		}
 		g.PendingCardItem = player.Hand[choice-1].FarmItemType
 		result := player.Farm.PlayCard(g.PendingCardItem, player.PlayChoices)
 		if result != nil {
@@ -244,10 +330,14 @@ func (g *gameState) provideDayInput(choice int) *PlayerInputNeeded {
 		// Card played successfully
 		player.Hand[choice-1] = HandItem{FarmItemType: NUM_FARM_ITEMS}
 		player.Hand.Sort()
-		g.DaySubStage = DaySubStageDraw
+		g.finishPlay()
 		return g.doPlayerDayTurn()
 
 	case DaySubStagePlay2Stack:
+		if choice == StackChoiceCancel {
+			g.DaySubStage = DaySubStagePlay2
+			return g.doPlayerDayTurn()
+		}
 		if choice == 0 { // New stack
 			player.Farm.makeStackWith(g.PendingCardItem)
 		} else { // Add to existing stack
@@ -261,21 +351,31 @@ func (g *gameState) provideDayInput(choice int) *PlayerInputNeeded {
 			}
 		}
 		player.Hand.Sort()
-		g.DaySubStage = DaySubStageDraw
+		g.finishPlay()
 		return g.doPlayerDayTurn()
 
+	case DaySubStageActionTarget, DaySubStageSabotageItem, DaySubStageTradeCard:
+		return g.provideActionInput(choice)
+
+	case DaySubStageMoveCard, DaySubStageMoveStack:
+		return g.provideMoveInput(choice)
+
 	case DaySubStageDraw:
 		player.Hand.Sort()
-		if choice == 1 {
-			player.Hand[3] = HandItem{FarmItemType: g.PublicDayCards[0]}
-			player.Hand[4] = HandItem{FarmItemType: g.PublicDayCards[1]}
-			g.dealPublicDayCards()
-		} else {
-			player.Hand[3] = HandItem{FarmItemType: g.nextDayCard()}
-			player.Hand[4] = HandItem{FarmItemType: g.nextDayCard()}
+		switch choice {
+		case DrawChoicePublic:
+			// Draws beyond the face-up row come from the deck
+			g.takePublicDayCards(player)
+			g.drawDayCards(player, g.Options.Rules.DrawsPerTurn-len(g.PublicDayCards))
+		case DrawChoiceMixed:
+			g.scavenge()
+		default:
+			g.drawDayCards(player, g.Options.Rules.DrawsPerTurn)
 		}
+		player.Hand.Sort()
 		// Turn complete - reset substage and advance player
 		g.DaySubStage = DaySubStageOptionalDiscard
+		g.PlaysMade = 0
 		g.PlayerTurnIndex++
 		g.nextPlayer()
 		return nil
@@ -293,14 +393,18 @@ func (g *gameState) doNightTurn() *PlayerInputNeeded {
 	// Deal night cards once at the start of night
 	if !g.NightCardsDealt {
 		for _, player := range g.Players {
-			for range g.NightNum {
-				player.Farm.NightCards = append(player.Farm.NightCards, g.nextNightCard())
+			g.dealNightCards(player, g.Options.Rules.NightCards.count(g.NightNum))
+			if g.isBossNight() {
+				player.Farm.NightCards = append(player.Farm.NightCards, NightCard{ZombieKey: g.tonightsBoss()})
 			}
 		}
 		g.NightCardsDealt = true
 		g.NightPlayerIndex = 0
 		g.NightPlayersToProcess = len(g.Players)
 		g.NightAnyCardProcessed = false
+		if g.EndReason != GameNotOver {
+			return nil
+		}
 	}
 
 	return g.processNightCards()
@@ -320,7 +424,7 @@ func (g *gameState) processNightCards() *PlayerInputNeeded {
 			return &PlayerInputNeeded{
 				Context:      InputContextConfirm,
 				RenderType:   RenderForNight,
-				Message:      fmt.Sprintf("%s: zombie auto-killed by %s!", g.CurrentPlayer().Name, g.LastUsedDefenseDesc),
+				Text:         NewText(MsgAutoKilled, g.CurrentPlayer().Name, g.LastUsedDefenseDesc),
 				ValidChoices: []int{0},
 			}
 
@@ -328,7 +432,7 @@ func (g *gameState) processNightCards() *PlayerInputNeeded {
 			return &PlayerInputNeeded{
 				Context:      InputContextConfirm,
 				RenderType:   RenderForNight,
-				Message:      fmt.Sprintf("%s: no defense available, will lose a life", g.CurrentPlayer().Name),
+				Text:         g.lifeLossText(MsgNoDefense, MsgNoDefenseInfect),
 				ValidChoices: []int{0},
 			}
 
@@ -336,7 +440,7 @@ func (g *gameState) processNightCards() *PlayerInputNeeded {
 			return &PlayerInputNeeded{
 				Context:      InputContextConfirm,
 				RenderType:   RenderForNight,
-				Message:      fmt.Sprintf("%s has been eliminated!", g.CurrentPlayer().Name),
+				Text:         NewText(MsgEliminated, g.CurrentPlayer().Name),
 				ValidChoices: []int{0},
 			}
 
@@ -347,7 +451,7 @@ func (g *gameState) processNightCards() *PlayerInputNeeded {
 			return &PlayerInputNeeded{
 				Context:      InputContextShield,
 				RenderType:   RenderForNight,
-				Message:      fmt.Sprintf("%s: use shield to save stack from exploding zombie?", g.CurrentPlayer().Name),
+				Text:         NewText(MsgShield, g.CurrentPlayer().Name),
 				ValidChoices: []int{1, 0},
 			}
 
@@ -355,7 +459,7 @@ func (g *gameState) processNightCards() *PlayerInputNeeded {
 			return &PlayerInputNeeded{
 				Context:      InputContextConfirm,
 				RenderType:   RenderForNight,
-				Message:      fmt.Sprintf("%s: will lose a life", g.CurrentPlayer().Name),
+				Text:         g.lifeLossText(MsgLifeLoss, MsgLifeLossInfect),
 				ValidChoices: []int{0},
 			}
 
@@ -364,12 +468,45 @@ func (g *gameState) processNightCards() *PlayerInputNeeded {
 			return &PlayerInputNeeded{
 				Context:      InputContextConfirm,
 				RenderType:   RenderForNight,
-				Message:      fmt.Sprintf("%s: %s", g.PendingEventName, g.PendingEventDesc),
+				Text:         NewText(MsgEvent, g.PendingEventName, NewText(eventKey(g.PendingEventName))),
 				ValidChoices: []int{0},
 			}
 
 		case NightSubStageEventDiscard:
 			return g.createEventDiscardInput()
+
+		case NightSubStageBossArrival:
+			return &PlayerInputNeeded{
+				Context:      InputContextConfirm,
+				RenderType:   RenderForNight,
+				Text:         NewText(MsgBossArrival, g.CurrentPlayer().Name, g.BossArrival),
+				ValidChoices: []int{0},
+			}
+
+		case NightSubStageBossFight:
+			return g.createBossFightInput()
+
+		case NightSubStageBossDefeated:
+			return &PlayerInputNeeded{
+				Context:      InputContextConfirm,
+				RenderType:   RenderForNight,
+				Text:         NewText(MsgBossDefeated, g.CurrentPlayer().Name, g.CurrentZombie.Name),
+				ValidChoices: []int{0},
+			}
+
+		case NightSubStageLendDefense:
+			return g.createLendInput()
+
+		case NightSubStageBait:
+			return g.createBaitInput()
+
+		case NightSubStageBossOverrun:
+			return &PlayerInputNeeded{
+				Context:      InputContextConfirm,
+				RenderType:   RenderForNight,
+				Text:         NewText(MsgBossOverrun, g.CurrentPlayer().Name, g.CurrentZombie.Name, g.bossHealthLeft()),
+				ValidChoices: []int{0},
+			}
 		}
 
 		// Normal processing
@@ -420,9 +557,31 @@ func (g *gameState) startEventDiscard(n int) *PlayerInputNeeded {
 	g.EventDiscardStartIdx = g.CurrentPlayerIdx // Start from player who drew the event
 	g.EventDiscardPlayerIdx = 0                 // Offset from start (0 = current player)
 	g.NightSubStage = NightSubStageEventDiscard
+	if g.Options.SimultaneousDiscards {
+		g.startSimultaneousDiscard()
+	}
 	return g.createEventDiscardInput()
 }
 
+// eventDiscardInput asks the player at idx to discard a farm card, with
+// remaining cards still to go.
+func (g *gameState) eventDiscardInput(idx int, remaining int) *PlayerInputNeeded {
+	player := g.Players[idx]
+	choices := make([]int, player.Farm.Stacks.TotalItems())
+	for j := range choices {
+		choices[j] = j + 1
+	}
+
+	discardNum := g.EventDiscardTotal - remaining + 1
+	return &PlayerInputNeeded{
+		Context:      InputContextEventDiscard,
+		RenderType:   RenderForDiscard,
+		Text:         NewText(MsgEventDiscard, player.Name, discardNum, g.EventDiscardTotal),
+		ValidChoices: choices,
+		SelectCount:  remaining,
+	}
+}
+
 func (g *gameState) processEventCard(nightCard NightCard) *PlayerInputNeeded {
 	// Save event info for confirmation display
 	g.PendingEventName = nightCard.Event.Name
@@ -435,6 +594,14 @@ func (g *gameState) processEventCard(nightCard NightCard) *PlayerInputNeeded {
 
 // createEventDiscardInput creates input request for event-based discards
 func (g *gameState) createEventDiscardInput() *PlayerInputNeeded {
+	if g.EventDiscardsLeft != nil {
+		if input := g.nextSimultaneousDiscard(); input != nil {
+			return input
+		}
+		g.EventDiscardsLeft = nil
+		g.EventDiscardPlayerIdx = len(g.Players)
+	}
+
 	// Find the player who needs to discard
 	// Players are processed starting from EventDiscardStartIdx and cycling through
 	for g.EventDiscardPlayerIdx < len(g.Players) {
@@ -462,18 +629,7 @@ func (g *gameState) createEventDiscardInput() *PlayerInputNeeded {
 		}
 
 		// Need player to choose
-		choices := make([]int, totalItems)
-		for j := range choices {
-			choices[j] = j + 1
-		}
-
-		discardNum := g.EventDiscardTotal - g.EventDiscardRemaining + 1
-		return &PlayerInputNeeded{
-			Context:      InputContextEventDiscard,
-			RenderType:   RenderForDiscard,
-			Message:      fmt.Sprintf("%s: choose card to discard (%d/%d)", player.Name, discardNum, g.EventDiscardTotal),
-			ValidChoices: choices,
-		}
+		return g.eventDiscardInput(actualIdx, g.EventDiscardRemaining)
 	}
 
 	// All players done discarding
@@ -500,6 +656,10 @@ func (g *gameState) processZombieCard(nightCard NightCard) *PlayerInputNeeded {
 	zc := ZombieChickens[nightCard.ZombieKey]
 	g.CurrentZombie = &zc
 
+	if zc.IsBoss() {
+		return g.processBossArrival(player, zc)
+	}
+
 	// Check for free kill first
 	freeStacks := player.Farm.FindStacksThatCanKillForFree(zc)
 	if len(freeStacks) > 0 {
@@ -511,9 +671,27 @@ func (g *gameState) processZombieCard(nightCard NightCard) *PlayerInputNeeded {
 		return g.processNightCards()
 	}
 
+	// A player with Bait may send the zombie to an opponent
+	if len(g.baitTargets(zc)) > 0 {
+		g.NightSubStage = NightSubStageBait
+		return g.processNightCards()
+	}
+
+	return g.chooseDefense(player, zc)
+}
+
+// chooseDefense asks the player how to defend against a zombie that wasn't
+// killed for free, or skips straight to the life loss if nothing can help.
+func (g *gameState) chooseDefense(player *Player, zc ZombieChicken) *PlayerInputNeeded {
 	// Check for any available defense
 	allStacks := player.Farm.FindStacksThatCanKill(zc)
 	if len(allStacks) == 0 {
+		// In co-op a neighbor may be able to help
+		if lender := g.findLender(zc); lender != -1 {
+			g.LenderIdx = lender
+			g.NightSubStage = NightSubStageLendDefense
+			return g.processNightCards()
+		}
 		g.NightSubStage = NightSubStageNoDefense
 		return g.processNightCards()
 	}
@@ -523,6 +701,149 @@ func (g *gameState) processZombieCard(nightCard NightCard) *PlayerInputNeeded {
 	return g.processNightCards()
 }
 
+// isBossNight returns true if bosses join the night cards tonight.
+func (g *gameState) isBossNight() bool {
+	return g.Options.BossEvery > 0 && g.NightNum%g.Options.BossEvery == 0
+}
+
+// tonightsBoss returns the ZombieChickens key of the boss every player faces tonight.
+// Bosses take turns in BossKeys order.
+func (g *gameState) tonightsBoss() int {
+	return BossKeys[(g.NightNum/g.Options.BossEvery-1)%len(BossKeys)]
+}
+
+// processBossArrival applies the boss's arrival rule to the current player's farm.
+// The fight itself starts once the player has confirmed what the boss did.
+func (g *gameState) processBossArrival(player *Player, zc ZombieChicken) *PlayerInputNeeded {
+	g.BossArrival = NewText(MsgBossArrives, zc.Name, zc.Health)
+
+	switch zc.BossRule {
+	case BossRuleWreck:
+		if idx := player.Farm.weakestStack(); idx != -1 {
+			g.BossArrival = NewText(MsgBossWrecks, zc.Name, player.Farm.Stacks[idx].String())
+			player.Farm.destroyStack(idx, g)
+		}
+	case BossRuleHatch:
+		if card, ok := g.nextZombieCard(); ok {
+			player.Farm.NightCards = append(player.Farm.NightCards, card)
+			g.BossArrival = NewText(MsgBossHatches, zc.Name, ZombieChickens[card.ZombieKey].Name)
+		}
+	}
+
+	g.NightSubStage = NightSubStageBossArrival
+	return g.processNightCards()
+}
+
+// bossHealthLeft returns how many more stacks the current player's boss can take.
+func (g *gameState) bossHealthLeft() int {
+	player := g.CurrentPlayer()
+	if g.CurrentZombie == nil || len(player.Farm.NightCards) == 0 {
+		return 0
+	}
+	return int(g.CurrentZombie.Health) - player.Farm.NightCards[0].Resolved
+}
+
+// createBossFightInput creates the input request for throwing a stack at a boss.
+// With no stack able to hurt the boss, the boss overruns the farm.
+func (g *gameState) createBossFightInput() *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+	zc := *g.CurrentZombie
+	allStacks := player.Farm.FindStacksThatCanKill(zc)
+	if len(allStacks) == 0 {
+		g.NightSubStage = NightSubStageBossOverrun
+		return g.processNightCards()
+	}
+
+	// Convert to 1-based indices for display
+	choices := make([]int, 0, len(allStacks)+1)
+	for _, idx := range allStacks {
+		choices = append(choices, idx+1)
+	}
+	choices = append(choices, -1)
+
+	healthLeft := g.bossHealthLeft()
+	return &PlayerInputNeeded{
+		Context:      InputContextDefense,
+		RenderType:   RenderForNight,
+		Text:         NewText(MsgBossFight, player.Name, zc.Name, healthLeft),
+		ValidChoices: choices,
+		ValidStacks:  allStacks,
+	}
+}
+
+// finishZombieCard deals with the zombie at the front of the player's night cards once
+// its attack has been resolved, then moves on to the next player. Most zombies are
+// discarded, but an Armored zombie that was fended off regroups behind the player's
+// other night cards, and a Swarm stays put for its second attack.
+func (g *gameState) finishZombieCard(player *Player, defended bool) *PlayerInputNeeded {
+	if len(player.Farm.NightCards) > 0 {
+		card := player.Farm.NightCards[0]
+		traits := ZombieChickens[card.ZombieKey].Traits
+		card.Resolved++
+
+		switch {
+		case defended && traits.HasTrait(Armored) && card.Resolved < 2:
+			// Armor cracked - it has to be defended again tonight
+			player.Farm.NightCards = append(player.Farm.NightCards[1:], card)
+		case traits.HasTrait(Swarm) && card.Resolved < 2:
+			// Second wave attacks on this player's next go
+			player.Farm.NightCards[0] = card
+		default:
+			if defended {
+				g.ZombiesKilled++
+				player.ZombiesKilled++
+				g.recordKill(card.ZombieKey)
+			}
+			g.discardNightCard(card)
+			player.Farm.NightCards = player.Farm.NightCards[1:]
+		}
+	}
+	return g.nextNightPlayer()
+}
+
+// dropZombieCard takes the zombie at the front of the player's night cards out
+// of play without discarding it, then moves on to the next player.
+func (g *gameState) dropZombieCard(player *Player) *PlayerInputNeeded {
+	if len(player.Farm.NightCards) > 0 {
+		player.Farm.NightCards = player.Farm.NightCards[1:]
+	}
+	return g.nextNightPlayer()
+}
+
+// nextNightPlayer moves on to the next player's night cards.
+func (g *gameState) nextNightPlayer() *PlayerInputNeeded {
+	g.NightPlayerIndex++
+	if len(g.Players) > 0 {
+		g.nextPlayer()
+	}
+	g.NightSubStage = NightSubStageProcessCards
+	return g.processNightCards()
+}
+
+// spreadContagion gives the next player another zombie when the current zombie
+// is Contagious and its attack went undefended.
+func (g *gameState) spreadContagion() {
+	if g.CurrentZombie == nil || !g.CurrentZombie.Traits.HasTrait(Contagious) || len(g.Players) < 2 {
+		return
+	}
+	card, ok := g.nextZombieCard()
+	if !ok {
+		return
+	}
+	neighbor := g.Players[(g.CurrentPlayerIdx+1)%len(g.Players)]
+	neighbor.Farm.NightCards = append(neighbor.Farm.NightCards, card)
+}
+
+// lifeLossText describes an undefended attack on the current player, with the
+// infect key used when a Contagious zombie spreads to the next player.
+func (g *gameState) lifeLossText(key MessageKey, infectKey MessageKey) Text {
+	if g.CurrentZombie != nil && g.CurrentZombie.Traits.HasTrait(Contagious) && len(g.Players) > 1 {
+		neighbor := g.Players[(g.CurrentPlayerIdx+1)%len(g.Players)]
+		return NewText(infectKey, g.CurrentPlayer().Name, neighbor.Name)
+	}
+	return NewText(key, g.CurrentPlayer().Name)
+}
+
 // createDefenseChoiceInput creates the input request for defense stack selection
 func (g *gameState) createDefenseChoiceInput() *PlayerInputNeeded {
 	player := g.CurrentPlayer()
@@ -539,7 +860,7 @@ func (g *gameState) createDefenseChoiceInput() *PlayerInputNeeded {
 	return &PlayerInputNeeded{
 		Context:      InputContextDefense,
 		RenderType:   RenderForNight,
-		Message:      fmt.Sprintf("%s: Select a stack to defend, or take damage", player.Name),
+		Text:         NewText(MsgDefense, player.Name),
 		ValidChoices: choices,
 		ValidStacks:  allStacks,
 	}
@@ -552,41 +873,25 @@ func (g *gameState) provideNightInput(choice int) *PlayerInputNeeded {
 	switch g.NightSubStage {
 	case NightSubStageZombieAutoKilled:
 		// Now remove the night card after confirmation
-		if g.CurrentNightCard != nil {
-			g.discardNightCard(*g.CurrentNightCard)
-		}
-		if len(player.Farm.NightCards) > 0 {
-			player.Farm.NightCards = player.Farm.NightCards[1:]
-		}
-		// Continue to next player
-		g.NightPlayerIndex++
-		if len(g.Players) > 0 {
-			g.nextPlayer()
-		}
-		g.NightSubStage = NightSubStageProcessCards
-		return g.processNightCards()
+		return g.finishZombieCard(player, true)
 
 	case NightSubStageNoDefense:
-		player.Lives--
-		if player.Lives <= 0 {
+		outOfLives := g.loseLives(player, 1)
+		g.spreadContagion()
+		if outOfLives {
 			g.NightSubStage = NightSubStageEliminated
 			return g.processNightCards()
 		}
-		// Remove the night card
-		if len(player.Farm.NightCards) > 0 {
-			g.discardNightCard(player.Farm.NightCards[0])
-			player.Farm.NightCards = player.Farm.NightCards[1:]
-		}
-		g.NightPlayerIndex++
-		if len(g.Players) > 0 {
-			g.nextPlayer()
-		}
-		g.NightSubStage = NightSubStageProcessCards
-		return g.processNightCards()
+		return g.finishZombieCard(player, false)
 
 	case NightSubStageEliminated:
 		playerToEliminate := player
-		g.eliminatePlayer(playerToEliminate)
+		cause := ""
+		if g.CurrentZombie != nil {
+			cause = g.CurrentZombie.Name
+		}
+		g.eliminateTeammates(playerToEliminate)
+		g.eliminatePlayer(playerToEliminate, cause)
 		g.NightPlayerIndex++
 		// Don't call NextPlayer - EliminatePlayer already adjusted indices
 		g.NightSubStage = NightSubStageProcessCards
@@ -619,51 +924,76 @@ func (g *gameState) provideNightInput(choice int) *PlayerInputNeeded {
 
 		// Use defense without shield
 		player.Farm.UseDefenseStack(g.ChosenStackIdx, zc, false, g)
-		if g.CurrentNightCard != nil {
-			g.discardNightCard(*g.CurrentNightCard)
-		}
-		if len(player.Farm.NightCards) > 0 {
-			player.Farm.NightCards = player.Farm.NightCards[1:]
-		}
-		g.NightPlayerIndex++
-		if len(g.Players) > 0 {
-			g.nextPlayer()
-		}
-		g.NightSubStage = NightSubStageProcessCards
-		return g.processNightCards()
+		return g.finishZombieCard(player, true)
 
 	case NightSubStageChooseShield:
 		useShield := choice == 1
 		zc := *g.CurrentZombie
 		player.Farm.UseDefenseStack(g.ChosenStackIdx, zc, useShield, g)
-		if g.CurrentNightCard != nil {
-			g.discardNightCard(*g.CurrentNightCard)
+		return g.finishZombieCard(player, true)
+
+	case NightSubStageConfirmLifeLoss:
+		outOfLives := g.loseLives(player, 1)
+		g.spreadContagion()
+		if outOfLives {
+			g.NightSubStage = NightSubStageEliminated
+			return g.processNightCards()
 		}
-		if len(player.Farm.NightCards) > 0 {
-			player.Farm.NightCards = player.Farm.NightCards[1:]
+		if !g.Options.ZombieExpansion {
+			// The base game doesn't discard a zombie the player let through
+			return g.dropZombieCard(player)
 		}
-		g.NightPlayerIndex++
-		if len(g.Players) > 0 {
-			g.nextPlayer()
+		return g.finishZombieCard(player, false)
+
+	case NightSubStageBossArrival:
+		g.NightSubStage = NightSubStageBossFight
+		return g.processNightCards()
+
+	case NightSubStageBossFight:
+		if choice == -1 {
+			g.NightSubStage = NightSubStageBossOverrun
+			return g.processNightCards()
+		}
+		// Any stack thrown at a boss is destroyed. A WOLR takes the boss down
+		// in one go, along with the rest of the farm.
+		if player.Farm.Stacks[choice-1].HasItem(WOLR) {
+			for len(player.Farm.Stacks) > 0 {
+				player.Farm.destroyStack(0, g)
+			}
+			player.Farm.NightCards[0].Resolved = int(g.CurrentZombie.Health)
+		} else {
+			player.Farm.destroyStack(choice-1, g)
+			player.Farm.NightCards[0].Resolved++
+		}
+		if g.bossHealthLeft() <= 0 {
+			g.NightSubStage = NightSubStageBossDefeated
 		}
-		g.NightSubStage = NightSubStageProcessCards
 		return g.processNightCards()
 
-	case NightSubStageConfirmLifeLoss:
-		player.Lives--
-		if player.Lives <= 0 {
+	case NightSubStageBossDefeated:
+		return g.finishZombieCard(player, true)
+
+	case NightSubStageBossOverrun:
+		if g.loseLives(player, g.bossHealthLeft()) {
 			g.NightSubStage = NightSubStageEliminated
 			return g.processNightCards()
 		}
-		if len(player.Farm.NightCards) > 0 {
-			player.Farm.NightCards = player.Farm.NightCards[1:]
+		return g.finishZombieCard(player, false)
+
+	case NightSubStageBait:
+		if choice == 0 {
+			return g.chooseDefense(player, *g.CurrentZombie)
 		}
-		g.NightPlayerIndex++
-		if len(g.Players) > 0 {
-			g.nextPlayer()
+		return g.baitZombie(choice - 1)
+
+	case NightSubStageLendDefense:
+		if choice == 0 {
+			g.NightSubStage = NightSubStageNoDefense
+			return g.processNightCards()
 		}
-		g.NightSubStage = NightSubStageProcessCards
-		return g.processNightCards()
+		lender := g.Players[g.LenderIdx]
+		lender.Farm.UseDefenseStack(choice-1, *g.CurrentZombie, false, g)
+		return g.finishZombieCard(player, true)
 
 	case NightSubStageEventConfirm:
 		// User confirmed - now run the event action
@@ -699,6 +1029,10 @@ func (g *gameState) provideNightInput(choice int) *PlayerInputNeeded {
 		actualIdx := (g.EventDiscardStartIdx + g.EventDiscardPlayerIdx) % len(g.Players)
 		player := g.Players[actualIdx]
 		player.Farm.RemoveItemByFlatIndex(choice-1, g)
+		if g.EventDiscardsLeft != nil {
+			g.EventDiscardsLeft[actualIdx]--
+			return g.createEventDiscardInput()
+		}
 		g.EventDiscardRemaining--
 
 		if g.EventDiscardRemaining <= 0 {
@@ -723,11 +1057,14 @@ func (g *gameState) resetNightState() {
 	g.CurrentNightCard = nil
 	g.CurrentZombie = nil
 	g.ChosenStackIdx = 0
+	g.LenderIdx = 0
 	g.EventDiscardStartIdx = 0
 	g.EventDiscardPlayerIdx = 0
 	g.EventDiscardRemaining = 0
 	g.EventDiscardTotal = 0
+	g.EventDiscardsLeft = nil
 	g.LastUsedDefenseDesc = ""
+	g.BossArrival = Text{}
 }
 
 // ContinueDay advances the game state through the current day cycle.
@@ -735,10 +1072,11 @@ func (g *gameState) resetNightState() {
 //
 // Returns:
 //   - (true, nil): Day completed successfully, game continues to next day
-//   - (false, nil): Game over (no players remaining)
+//   - (false, nil): Game over; EndReason says why
 //   - (_, *PlayerInputNeeded): Player input required; call ContinueAfterInput with the choice
 //
-// The game progresses through Morning, Afternoon, and Night phases.
+// The game progresses through Morning, Afternoon, and Night phases, with a
+// Day phase before the Night in co-op.
 // Each phase may require multiple calls if player input is needed.
 //
 // Example usage:
@@ -754,12 +1092,36 @@ func (g *gameState) resetNightState() {
 //	    }
 //	}
 func (g *gameState) ContinueDay() (bool, *PlayerInputNeeded) {
+	cont, inputNeeded := g.continueDay()
+	if choice, ok := g.nightAutoChoice(inputNeeded); ok {
+		// Answered like any other prompt, so the record has it too
+		g.lastInput = inEnglish(inputNeeded)
+		return g.ContinueAfterInput(choice)
+	}
+	g.lastInput = inEnglish(g.attachTip(g.attachDefault(inputNeeded)))
+	return cont, g.lastInput
+}
+
+// continueDay does the work of ContinueDay, before any tutorial tip is attached.
+func (g *gameState) continueDay() (bool, *PlayerInputNeeded) {
 	if len(g.Players) == 0 {
+		g.endGame(GameEndAllEliminated)
+	}
+	if g.EndReason != GameNotOver {
 		return false, nil
 	}
 
 	// Process based on current turn
 	switch g.Turn {
+	case Setup:
+		if inputNeeded := g.doSetupTurn(); inputNeeded != nil {
+			return true, inputNeeded
+		}
+		// Opening hands are settled - the first day starts
+		g.Turn = Morning
+		g.StageInTurn = OptionalDiscard
+		return g.ContinueDay()
+
 	case Morning:
 		for g.PlayerTurnIndex < len(g.Players) {
 			inputNeeded := g.doPlayerDayTurn()
@@ -781,18 +1143,50 @@ func (g *gameState) ContinueDay() (bool, *PlayerInputNeeded) {
 			}
 			// Player turn completed (PlayerTurnIndex incremented in provideDayInput)
 		}
-		// Afternoon complete - move to night
+		// Afternoon complete - move to night, or to the Day turn in co-op
+		g.PlayerTurnIndex = 0
+		g.Turn = Night
+		if g.isCoop() {
+			g.Turn = Day
+		}
+		return g.ContinueDay()
+
+	case Day:
+		for g.PlayerTurnIndex < len(g.Players) {
+			inputNeeded := g.doCoopDayTurn()
+			if inputNeeded != nil {
+				return true, inputNeeded
+			}
+			// Player turn completed (PlayerTurnIndex incremented in endCoopDayTurn)
+		}
+		// Day complete - move to night
 		g.PlayerTurnIndex = 0
 		g.Turn = Night
 		return g.ContinueDay()
 
 	case Night:
 		inputNeeded := g.doNightTurn()
+		if g.EndReason != GameNotOver {
+			// A co-op team can be defeated partway through the night
+			return false, nil
+		}
 		if inputNeeded != nil {
 			return true, inputNeeded
 		}
 		// Night complete
 		if len(g.Players) == 0 {
+			g.endGame(GameEndAllEliminated)
+		} else {
+			g.NightsSurvived++
+		}
+		g.checkLevelGoal()
+		if g.isCoop() && g.NightNum >= g.coopNights() {
+			g.endGame(GameEndTeamSurvived)
+		}
+		if !g.isCoop() && g.Options.MaxNights > 0 && g.NightNum >= g.Options.MaxNights && g.hasSoleLeader() {
+			g.endGame(GameEndMaxNights)
+		}
+		if g.EndReason != GameNotOver {
 			return false, nil
 		}
 		// Prepare for next day - reset NightCardsDealt here so next night will deal new cards
@@ -812,7 +1206,7 @@ func (g *gameState) ContinueDay() (bool, *PlayerInputNeeded) {
 //
 // Returns:
 //   - (true, nil): Current operation completed, game continues
-//   - (false, nil): Game over (no players remaining)
+//   - (false, nil): Game over; EndReason says why
 //   - (_, *PlayerInputNeeded): More input required; call again with the new choice
 //
 // Example:
@@ -823,15 +1217,86 @@ func (g *gameState) ContinueDay() (bool, *PlayerInputNeeded) {
 //	    gameOver, inputNeeded = game.ContinueAfterInput(choice)
 //	}
 func (g *gameState) ContinueAfterInput(choice int) (bool, *PlayerInputNeeded) {
+	g.saveUndo()
+	g.recordMove(choice)
 	inputNeeded := g.provideInput(choice)
+	if g.EndReason != GameNotOver {
+		return false, nil
+	}
 	if inputNeeded != nil {
-		return false, inputNeeded
+		if choice, ok := g.nightAutoChoice(inputNeeded); ok {
+			g.lastInput = inEnglish(inputNeeded)
+			return g.ContinueAfterInput(choice)
+		}
+		g.lastInput = inEnglish(g.attachTip(g.attachDefault(inputNeeded)))
+		return false, g.lastInput
 	}
 
 	// Continue the day
 	return g.ContinueDay()
 }
 
+// ContinueAfterInputs answers a multi-select prompt (SelectCount > 0) with all
+// of its choices at once. Like ContinueAfterInput, the choices must already be
+// validated, see PlayerInputNeeded.ValidSelection.
+//
+// The choices are flat indices into the farm as it was shown, so they are
+// applied from the highest down: removing an item never shifts the ones
+// before it.
+func (g *gameState) ContinueAfterInputs(choices []int) (bool, *PlayerInputNeeded) {
+	return g.ContinueAfterInputsFrom(g.activeInputPlayerIdx(), choices)
+}
+
+// ContinueAfterInputsFrom is ContinueAfterInputs for the prompt waiting on the
+// given player; see ContinueAfterInputFrom.
+func (g *gameState) ContinueAfterInputsFrom(playerIdx int, choices []int) (bool, *PlayerInputNeeded) {
+	choices = append([]int{}, choices...)
+	sort.Sort(sort.Reverse(sort.IntSlice(choices)))
+
+	var cont bool
+	var inputNeeded *PlayerInputNeeded
+	for _, choice := range choices {
+		cont, inputNeeded = g.ContinueAfterInputFrom(playerIdx, choice)
+	}
+	return cont, inputNeeded
+}
+
+// endGame records why the game ended. The first reason given sticks.
+func (g *gameState) endGame(reason GameEndReason) {
+	if g.EndReason == GameNotOver {
+		g.EndReason = reason
+		if len(g.Players) == 1 {
+			g.countCardsLeft(g.Players[0])
+		}
+	}
+}
+
+// hasSoleLeader returns true if exactly one player (or team) has the most lives.
+// While players are tied at the top, a MaxNights game plays on in sudden death.
+func (g *gameState) hasSoleLeader() bool {
+	most, leaders := 0, map[int]bool{}
+	for _, player := range g.Players {
+		switch {
+		case player.Lives > most:
+			most, leaders = player.Lives, map[int]bool{player.Team: true}
+		case player.Lives == most:
+			leaders[player.Team] = true
+		}
+	}
+	return len(leaders) == 1
+}
+
+// Standings returns the remaining players ranked by lives, most first.
+// Players with the same lives keep their turn order.
+func (g *gameState) Standings() Players {
+	standings := make(Players, len(g.Players))
+	copy(standings, g.Players)
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Lives > standings[j].Lives
+	})
+	return standings
+}
+
 // HasLivingPlayers returns true if at least one player has lives remaining.
 // Note that eliminated players are removed from the Players slice, so this
 // is equivalent to checking len(g.Players) > 0 in most cases.
@@ -0,0 +1,51 @@
+package zcgame
+
+import "testing"
+
+func TestMaxNights(t *testing.T) {
+	for seed := range int64(20) {
+		g, _ := simulateGame(t, GameOptions{MaxNights: 3, Seed: seed + 1}, "a", "b", "c")
+		if g.EndReason != GameEndMaxNights {
+			continue
+		}
+		if g.NightNum < 3 {
+			t.Errorf("the game ended on night %d, before the limit", g.NightNum)
+		}
+		// Ties at the top play on in sudden death
+		if standings := g.Standings(); len(standings) > 1 && standings[0].Lives == standings[1].Lives {
+			t.Errorf("the game ended with %s and %s tied on %d lives", standings[0].Name, standings[1].Name, standings[0].Lives)
+		}
+	}
+}
+
+func TestEventDiscardSelection(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	g := view.game
+	g.Turn = Night
+	g.Players[0].Farm.Stacks = Stacks{{Shotgun, Ammo}, {Scarecrow}, {HayBale}}
+	g.Players[1].Farm.Stacks = Stacks{{Shotgun, Ammo}, {Scarecrow}, {HayBale}}
+
+	input := g.startEventDiscard(2)
+	if input.SelectCount != 2 {
+		t.Fatalf("expected to pick 2 cards at once, got %d", input.SelectCount)
+	}
+	if input.ValidSelection([]int{1, 1}) || input.ValidSelection([]int{1}) || input.ValidSelection([]int{1, 5}) {
+		t.Error("expected repeated, missing and out of range picks to be refused")
+	}
+	if !input.ValidSelection([]int{1, 4}) {
+		t.Fatal("expected the Shotgun and Hay Bale to be a valid pick")
+	}
+
+	// Both indices refer to the farm as shown, before either card is removed
+	_, input = view.ContinueAfterInputs([]int{1, 4})
+	expected := Stacks{{Ammo}, {Scarecrow}}
+	if g.Players[0].Farm.Stacks.String() != expected.String() {
+		t.Errorf("expected %s after the discards, got %s", expected, g.Players[0].Farm.Stacks)
+	}
+	if view.ActiveInputPlayerIdx() != 1 || input.SelectCount != 2 {
+		t.Errorf("expected p2 to pick 2 cards next, got player %d picking %d", view.ActiveInputPlayerIdx(), input.SelectCount)
+	}
+}
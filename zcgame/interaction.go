@@ -0,0 +1,240 @@
+package zcgame
+
+// Interaction Cards
+//
+// With GameOptions.InteractionCards, InteractionDayCardAmounts adds cards that
+// let farms act on each other:
+//   - Bait sits alone on the farm. When a zombie attacks, the player may send
+//     it to an opponent instead, using up the Bait.
+//   - Sabotage is played from the hand: discard one item from an opponent's farm.
+//   - Trade is played from the hand: give a neighbor a card and take a random
+//     card from their hand.
+//
+// Sabotage and Trade are action cards. They never reach the farm, and can only
+// be played when they have a target.
+
+// IsAction returns true if the card is played against another player
+// instead of being placed on the farm.
+func (f FarmItemType) IsAction() bool {
+	return f == Sabotage || f == Trade
+}
+
+// opponents returns the indices of the players not on the current player's team.
+func (g *gameState) opponents() []int {
+	player := g.CurrentPlayer()
+	result := []int{}
+	for i, p := range g.Players {
+		if p.Team != player.Team {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// neighbors returns the indices of the next and previous players, without repeats.
+func (g *gameState) neighbors() []int {
+	if len(g.Players) < 2 {
+		return []int{}
+	}
+	next := (g.CurrentPlayerIdx + 1) % len(g.Players)
+	prev := (g.CurrentPlayerIdx + len(g.Players) - 1) % len(g.Players)
+	if next == prev {
+		return []int{next}
+	}
+	return []int{next, prev}
+}
+
+// actionTargets returns the indices of the players an action card can be played on.
+func (g *gameState) actionTargets(item FarmItemType) []int {
+	targets := []int{}
+	switch item {
+	case Sabotage:
+		for _, idx := range g.opponents() {
+			if g.Players[idx].Farm.Stacks.TotalItems() > 0 {
+				targets = append(targets, idx)
+			}
+		}
+	case Trade:
+		for _, idx := range g.neighbors() {
+			if g.Players[idx].Hand.Count() > 0 {
+				targets = append(targets, idx)
+			}
+		}
+	}
+	return targets
+}
+
+// playableChoices returns the 1-based hand indices the current player can play.
+// Action cards without a target can't be played.
+func (g *gameState) playableChoices() []int {
+	player := g.CurrentPlayer()
+	choices := []int{}
+	for _, choice := range player.Hand.choices() {
+		item := player.Hand[choice-1].FarmItemType
+		if item.IsAction() && len(g.actionTargets(item)) == 0 {
+			continue
+		}
+		if item == Trade && player.Hand.Count() < 2 {
+			// Nothing to give besides the Trade itself
+			continue
+		}
+		choices = append(choices, choice)
+	}
+	return choices
+}
+
+// startAction plays the action card at the 1-based hand index. The card is
+// discarded straight away, and the turn moves on to the next play once the
+// action is resolved.
+func (g *gameState) startAction(choice int) *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+	g.PendingCardItem = player.Hand[choice-1].FarmItemType
+	g.discardDayCard(g.PendingCardItem)
+	player.Hand[choice-1] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+	player.Hand.Sort()
+
+	if targets := g.actionTargets(g.PendingCardItem); len(targets) == 1 {
+		return g.chooseActionTarget(targets[0])
+	}
+	g.DaySubStage = DaySubStageActionTarget
+	return g.doPlayerDayTurn()
+}
+
+// createActionTargetInput asks the current player who to play the action card on.
+func (g *gameState) createActionTargetInput() *PlayerInputNeeded {
+	choices := []int{}
+	labels := map[int]Text{}
+	for _, idx := range g.actionTargets(g.PendingCardItem) {
+		choices = append(choices, idx+1)
+		labels[idx+1] = plainText(g.Players[idx].Name)
+	}
+	return &PlayerInputNeeded{
+		Context:      InputContextChoosePlayer,
+		RenderType:   RenderNormal,
+		Text:         NewText(MsgActionTarget, g.PendingCardItem),
+		ValidChoices: choices,
+		LabelTexts:   labels,
+	}
+}
+
+// chooseActionTarget records the target of the action card and asks for the
+// card or item the action needs.
+func (g *gameState) chooseActionTarget(targetIdx int) *PlayerInputNeeded {
+	g.ActionTargetIdx = targetIdx
+	if g.PendingCardItem == Sabotage {
+		g.DaySubStage = DaySubStageSabotageItem
+	} else {
+		g.DaySubStage = DaySubStageTradeCard
+	}
+	return g.doPlayerDayTurn()
+}
+
+// createSabotageInput asks which item to discard from the target's farm.
+// Item indices refer to the target's farm, as with event discards.
+func (g *gameState) createSabotageInput() *PlayerInputNeeded {
+	target := g.Players[g.ActionTargetIdx]
+	choices := make([]int, target.Farm.Stacks.TotalItems())
+	for i := range choices {
+		choices[i] = i + 1
+	}
+	return &PlayerInputNeeded{
+		Context:      InputContextSabotage,
+		RenderType:   RenderForDiscard,
+		Text:         NewText(MsgSabotage, target.Name),
+		ValidChoices: choices,
+	}
+}
+
+// createTradeInput asks which hand card to give the target.
+func (g *gameState) createTradeInput() *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+	return &PlayerInputNeeded{
+		Context:      InputContextTrade,
+		RenderType:   RenderNormal,
+		Text:         NewText(MsgTrade, g.Players[g.ActionTargetIdx].Name),
+		ValidChoices: player.Hand.choices(),
+	}
+}
+
+// provideActionInput handles input while resolving an action card.
+func (g *gameState) provideActionInput(choice int) *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+
+	switch g.DaySubStage {
+	case DaySubStageActionTarget:
+		return g.chooseActionTarget(choice - 1)
+
+	case DaySubStageSabotageItem:
+		g.Players[g.ActionTargetIdx].Farm.RemoveItemByFlatIndex(choice-1, g)
+
+	case DaySubStageTradeCard:
+		target := g.Players[g.ActionTargetIdx]
+		given := player.Hand[choice-1].FarmItemType
+		player.Hand[choice-1] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+
+		// Take a random card before handing over the given one; the card
+		// taken can't be undone
+		g.clearUndo()
+		target.Hand.Sort()
+		takeIdx := g.rng.Intn(target.Hand.Count())
+		taken := target.Hand[takeIdx].FarmItemType
+		target.Hand[takeIdx] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+		target.Hand.add(given)
+		target.Hand.Sort()
+		player.Hand.add(taken)
+	}
+
+	player.Hand.Sort()
+	g.finishPlay()
+	return g.doPlayerDayTurn()
+}
+
+// baitTargets returns the indices of the opponents the current player could
+// bait the zombie to, or nil if they have no Bait or the zombie is a boss.
+func (g *gameState) baitTargets(zc ZombieChicken) []int {
+	if zc.IsBoss() || !g.CurrentPlayer().Farm.HasItemInStacks(Bait) {
+		return nil
+	}
+	return g.opponents()
+}
+
+// createBaitInput asks whether to send the zombie to an opponent.
+func (g *gameState) createBaitInput() *PlayerInputNeeded {
+	choices := []int{}
+	labels := map[int]Text{0: NewText(MsgBaitKeep)}
+	for _, idx := range g.baitTargets(*g.CurrentZombie) {
+		choices = append(choices, idx+1)
+		labels[idx+1] = plainText(g.Players[idx].Name)
+	}
+	choices = append(choices, 0)
+	return &PlayerInputNeeded{
+		Context:      InputContextChoosePlayer,
+		RenderType:   RenderForNight,
+		Text:         NewText(MsgBait, g.CurrentPlayer().Name, g.CurrentZombie.Name),
+		ValidChoices: choices,
+		LabelTexts:   labels,
+	}
+}
+
+// baitZombie moves the current zombie to the back of the target's night cards
+// and uses up the current player's Bait.
+func (g *gameState) baitZombie(targetIdx int) *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+	for i, stack := range player.Farm.Stacks {
+		if stack.HasItem(Bait) {
+			player.Farm.destroyStack(i, g)
+			break
+		}
+	}
+
+	card := player.Farm.NightCards[0]
+	card.Resolved = 0
+	player.Farm.NightCards = player.Farm.NightCards[1:]
+	target := g.Players[targetIdx]
+	target.Farm.NightCards = append(target.Farm.NightCards, card)
+
+	g.NightPlayerIndex++
+	g.nextPlayer()
+	g.NightSubStage = NightSubStageProcessCards
+	return g.processNightCards()
+}
@@ -0,0 +1,113 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+)
+
+// handIndex returns the 1-based hand choice for the first copy of item.
+func handIndex(t *testing.T, hand Hand, item FarmItemType) int {
+	t.Helper()
+	for i, card := range hand {
+		if card.FarmItemType == item {
+			return i + 1
+		}
+	}
+	t.Fatalf("no %s in hand %s", item, hand)
+	return 0
+}
+
+// countHand returns how many copies of item are in hand.
+func countHand(hand Hand, item FarmItemType) int {
+	count := 0
+	for _, card := range hand {
+		if card.FarmItemType == item {
+			count++
+		}
+	}
+	return count
+}
+
+// startPlay deals the first player the given card and skips their discard,
+// returning the prompt to play a card.
+func startPlay(t *testing.T, g *gameState, item FarmItemType) *PlayerInputNeeded {
+	t.Helper()
+	g.Players[0].Hand[0] = HandItem{FarmItemType: item}
+	g.Players[0].Hand.Sort()
+	_, input := g.ContinueDay()
+	if input == nil || input.Context != InputContextDiscard {
+		t.Fatalf("expected the opening discard, got %v", input)
+	}
+	_, input = g.ContinueAfterInput(0)
+	return input
+}
+
+func TestBait(t *testing.T) {
+	g := newTestGame(t, GameOptions{InteractionCards: true}, "a", "b", "c")
+	g.Players[0].Farm.Stacks = Stacks{{Bait}}
+
+	input := startNight(t, g, NightCards{{ZombieKey: 8}})
+	if input.Context != InputContextChoosePlayer || !slices.Equal(input.ValidChoices, []int{2, 3, 0}) {
+		t.Fatalf("expected to choose an opponent to bait, got %q %v", input.Message, input.ValidChoices)
+	}
+	g.ContinueAfterInput(3)
+	if len(g.Players[0].Farm.Stacks) != 0 || len(g.Players[0].Farm.NightCards) != 0 {
+		t.Errorf("the Bait and the zombie should be gone, got %s and %v", g.Players[0].Farm.Stacks, g.Players[0].Farm.NightCards)
+	}
+	if cards := g.Players[2].Farm.NightCards; len(cards) != 1 || cards[0].ZombieKey != 8 {
+		t.Errorf("the zombie should be sent to c, got %v", cards)
+	}
+}
+
+func TestSabotage(t *testing.T) {
+	g := newTestGame(t, GameOptions{InteractionCards: true}, "a", "b", "c")
+	g.Players[1].Farm.Stacks = Stacks{{Shotgun, Ammo}}
+
+	input := startPlay(t, g, Sabotage)
+	sabotages := countHand(g.Players[0].Hand, Sabotage)
+	_, input = g.ContinueAfterInput(handIndex(t, g.Players[0].Hand, Sabotage))
+	if input.Context != InputContextSabotage {
+		t.Fatalf("expected b, the only farm with items, to be sabotaged, got %q", input.Message)
+	}
+	if idx := g.stackChoicePlayerIdx(); idx != 1 {
+		t.Errorf("the items to sabotage should be on b's farm, got player %d", idx)
+	}
+	g.ContinueAfterInput(2)
+	if stacks := g.Players[1].Farm.Stacks; len(stacks) != 1 || stacks[0].HasItem(Ammo) {
+		t.Errorf("expected b's Ammo to be discarded, got %s", stacks)
+	}
+	if countHand(g.Players[0].Hand, Sabotage) != sabotages-1 {
+		t.Error("the Sabotage should leave the hand")
+	}
+}
+
+func TestTrade(t *testing.T) {
+	g := newTestGame(t, GameOptions{InteractionCards: true}, "a", "b", "c")
+	input := startPlay(t, g, Trade)
+	_, input = g.ContinueAfterInput(handIndex(t, g.Players[0].Hand, Trade))
+	if input.Context != InputContextChoosePlayer || !slices.Equal(input.ValidChoices, []int{2, 3}) {
+		t.Fatalf("expected to choose between both neighbors, got %q %v", input.Message, input.ValidChoices)
+	}
+
+	_, input = g.ContinueAfterInput(2)
+	if input.Context != InputContextTrade {
+		t.Fatalf("expected to choose a card to give, got %q", input.Message)
+	}
+	player, target := g.Players[0], g.Players[1]
+	given := player.Hand[0].FarmItemType
+	handSize, targetSize := player.Hand.Count(), target.Hand.Count()
+	g.ContinueAfterInput(1)
+	if player.Hand.Count() != handSize || target.Hand.Count() != targetSize {
+		t.Errorf("a trade swaps one card each way, got %d and %d cards instead of %d and %d",
+			player.Hand.Count(), target.Hand.Count(), handSize, targetSize)
+	}
+	if !slices.ContainsFunc(target.Hand, func(h HandItem) bool { return h.FarmItemType == given }) {
+		t.Errorf("b should have been given %s, got %s", given, target.Hand)
+	}
+}
+
+func TestInteractionGames(t *testing.T) {
+	for seed := range int64(20) {
+		simulateGame(t, GameOptions{InteractionCards: true, Seed: seed + 1}, "a", "b", "c")
+	}
+}
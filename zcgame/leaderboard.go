@@ -0,0 +1,89 @@
+package zcgame
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"time"
+)
+
+// LeaderboardEntry is one finished solo game on the leaderboard.
+type LeaderboardEntry struct {
+	Name     string    `json:"name"`
+	Score    SoloScore `json:"score"`
+	Seed     int64     `json:"seed"`
+	Daily    string    `json:"daily,omitempty"` // Date of the daily challenge (see DailyDate), empty for other games
+	PlayedAt time.Time `json:"played_at"`
+}
+
+// Leaderboard holds solo scores, best first.
+type Leaderboard struct {
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// LoadLeaderboard reads a leaderboard file. A missing file is an empty leaderboard.
+func LoadLeaderboard(path string) (*Leaderboard, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Leaderboard{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lb Leaderboard
+	if err := json.Unmarshal(data, &lb); err != nil {
+		return nil, err
+	}
+	return &lb, nil
+}
+
+// Save writes the leaderboard to a file.
+func (lb *Leaderboard) Save(path string) error {
+	data, err := json.MarshalIndent(lb, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Add records an entry, keeping the entries sorted best first.
+// Ties go to the earlier game.
+func (lb *Leaderboard) Add(entry LeaderboardEntry) {
+	lb.Entries = append(lb.Entries, entry)
+	sort.SliceStable(lb.Entries, func(i, j int) bool {
+		return lb.Entries[i].Score.Total > lb.Entries[j].Score.Total
+	})
+}
+
+// Top returns up to n of the best entries. With a daily date, only that day's
+// daily challenge entries are returned.
+func (lb *Leaderboard) Top(n int, daily string) []LeaderboardEntry {
+	result := []LeaderboardEntry{}
+	for _, entry := range lb.Entries {
+		if len(result) == n {
+			break
+		}
+		if daily == "" || entry.Daily == daily {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// RecordSoloGame adds a finished solo game to the leaderboard file at path.
+// daily is the DailyDate of a daily challenge, or empty for other games.
+func RecordSoloGame(path string, v GameView, name string, daily string) (*Leaderboard, error) {
+	lb, err := LoadLeaderboard(path)
+	if err != nil {
+		return nil, err
+	}
+	lb.Add(LeaderboardEntry{
+		Name:     name,
+		Score:    v.SoloScore(),
+		Seed:     v.Seed(),
+		Daily:    daily,
+		PlayedAt: time.Now(),
+	})
+	return lb, lb.Save(path)
+}
@@ -0,0 +1,65 @@
+package zcgame
+
+// Campaign holds the campaign levels in the order they are played. Early
+// levels introduce one defense each with a night deck built around it; later
+// ones play closer to a full game.
+var Campaign = []Level{
+	{
+		ID:    "hay-wall",
+		Name:  "Hold the Fence",
+		Intro: "Three Hay Bales make a Hay Wall, which stops any zombie that can't fly, climb or blow it up.\nYour wall is already built. Keep it standing for two nights.",
+		Lives: 3,
+		Farm:  Stacks{{HayBale, HayBale, HayBale}},
+		Hand:  []FarmItemType{HayBale, HayBale, HayBale},
+		Script: []string{
+			"Creeper",
+			"Chomper", "Creeper",
+		},
+		Weights: map[string]int{"Creeper": 6, "Chomper": 2, "Walker": 2},
+		Goal:    LevelGoal{Nights: 2},
+	},
+	{
+		ID:      "scarecrow",
+		Name:    "Boo!",
+		Intro:   "Timid zombies run from a Scarecrow, even the ones that fly or can't be seen.\nSurvive three nights without dropping below 2 lives.",
+		Lives:   3,
+		Hand:    []FarmItemType{Scarecrow, Scarecrow},
+		Weights: map[string]int{"Chomper": 4, "Floater": 4, "Blaster": 2, "Thunder": 2, "Creeper": 2},
+		Goal:    LevelGoal{Nights: 3, Lives: 2},
+	},
+	{
+		ID:      "shotgun",
+		Name:    "Clay Pigeons",
+		Intro:   "A Shotgun with Ammo blasts a zombie out of the sky. Each shot uses up one Ammo.\nKill 4 Biters within 5 nights.",
+		Options: GameOptions{MaxNights: 5},
+		Farm:    Stacks{{Shotgun, Ammo}},
+		Hand:    []FarmItemType{Ammo, Ammo, Shotgun},
+		Script:  []string{"Biter"},
+		Weights: map[string]int{"Biter": 10, "Kablooey": 2, "Creeper": 2},
+		Goal:    LevelGoal{Kills: 4, Zombie: "Biter"},
+	},
+	{
+		ID:     "storm",
+		Name:   "Storm Season",
+		Intro:  "Not every night card is a zombie. Events shake up every farm at once.\nThe weather is turning: survive four nights with 2 lives.",
+		Lives:  4,
+		Script: []string{"Creeper", "Lightning Storm", "Walker", "Blood Moon", "Biter", "Chomper"},
+		Goal:   LevelGoal{Nights: 4, Lives: 2},
+	},
+	{
+		ID:      "boomers",
+		Name:    "Fire Drill",
+		Intro:   "Boomers fly, shrug off bullets and explode. Only fire will do, and a Shield keeps your Flamethrower from going up with them.\nKill 3 Boomers within 6 nights.",
+		Options: GameOptions{MaxNights: 6},
+		Hand:    []FarmItemType{Flamethrower, Fuel, Shield, Shield},
+		Weights: map[string]int{"Boomer": 6, "Walker": 3, "Biter": 3},
+		Goal:    LevelGoal{Kills: 3, Zombie: "Boomer"},
+	},
+	{
+		ID:      "graduation",
+		Name:    "The Long Night",
+		Intro:   "Everything you have learned, with the defense expansion and a boss every third night.\nSurvive six nights.",
+		Options: GameOptions{DefenseExpansion: true, BossEvery: 3},
+		Goal:    LevelGoal{Nights: 6},
+	},
+}
@@ -0,0 +1,215 @@
+package zcgame
+
+// Messages
+//
+// Prompts, choice labels, tips, event descriptions and stage names are Texts:
+// a MessageKey into the message catalog plus the arguments its format takes.
+// The engine fills in the English PlayerInputNeeded.Message, ChoiceLabels and
+// Tip, so frontends that only speak English can ignore all of this. Other
+// frontends call Localized with the player's Language and render the copy it
+// returns. Card, zombie and player names are passed through as they are.
+//
+// A key missing from a language falls back to English. Every language must
+// take the same arguments in its formats; use explicit argument indexes such
+// as %[2]s when a translation needs them in another order.
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Language is a language the message catalog has translations for.
+type Language string
+
+const (
+	English Language = "en"
+	Spanish Language = "es"
+)
+
+// Languages lists every language in the message catalog.
+var Languages = []Language{English, Spanish}
+
+// ParseLanguage parses a Language code such as "es".
+func ParseLanguage(s string) (Language, error) {
+	if lang := Language(s); slices.Contains(Languages, lang) {
+		return lang, nil
+	}
+	return "", fmt.Errorf("unknown language %q (want en or es)", s)
+}
+
+// Name returns the language's name in that language.
+func (l Language) Name() string {
+	switch l {
+	case Spanish:
+		return "Español"
+	default:
+		return "English"
+	}
+}
+
+// MatchLanguage picks the catalog language a browser prefers most from an
+// Accept-Language header such as "es-MX,es;q=0.9,en;q=0.8". Returns English
+// if none of the header's languages are in the catalog.
+func MatchLanguage(acceptLanguage string) Language {
+	best, bestQ := English, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if lang, err := ParseLanguage(primary); err == nil && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// MessageKey names a format string in the message catalog.
+type MessageKey string
+
+// Text is a message from the catalog, ready to be rendered in any Language.
+type Text struct {
+	Key  MessageKey
+	Args []any // Format arguments; Text arguments are localized too
+}
+
+// NewText returns the catalog message for key with the given arguments.
+func NewText(key MessageKey, args ...any) Text {
+	return Text{Key: key, Args: args}
+}
+
+// plainText wraps a string that isn't translated, such as a player or card
+// name, so it can be used where a Text is expected.
+func plainText(s string) Text {
+	return NewText(MsgPlain, s)
+}
+
+// countText picks the singular key when n is 1, and otherwise the plural key,
+// which takes n as its last argument.
+func countText(n int, one MessageKey, many MessageKey, args ...any) Text {
+	if n == 1 {
+		return NewText(one, args...)
+	}
+	return NewText(many, append(slices.Clone(args), n)...)
+}
+
+// IsZero returns true if the Text is empty.
+func (t Text) IsZero() bool {
+	return t.Key == ""
+}
+
+// In renders the text in the given language.
+func (t Text) In(lang Language) string {
+	if t.IsZero() {
+		return ""
+	}
+	format, ok := catalog[lang][t.Key]
+	if !ok {
+		format, ok = catalog[English][t.Key]
+	}
+	if !ok {
+		return string(t.Key)
+	}
+	args := make([]any, len(t.Args))
+	for i, arg := range t.Args {
+		if text, ok := arg.(Text); ok {
+			arg = text.In(lang)
+		}
+		args[i] = arg
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// String renders the text in English.
+func (t Text) String() string {
+	return t.In(English)
+}
+
+// Localized returns a copy of the input with Message, ChoiceLabels and Tip
+// rendered in the given language.
+func (p *PlayerInputNeeded) Localized(lang Language) *PlayerInputNeeded {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.localize(lang)
+	return &c
+}
+
+// inEnglish fills in the input's English Message, ChoiceLabels and Tip before
+// it is handed out.
+func inEnglish(input *PlayerInputNeeded) *PlayerInputNeeded {
+	if input != nil {
+		input.localize(English)
+	}
+	return input
+}
+
+// localize renders the input's Texts into Message, ChoiceLabels and Tip.
+func (p *PlayerInputNeeded) localize(lang Language) {
+	if !p.Text.IsZero() {
+		p.Message = p.Text.In(lang)
+	}
+	if p.LabelTexts != nil {
+		p.ChoiceLabels = make(map[int]string, len(p.LabelTexts))
+		for choice, text := range p.LabelTexts {
+			p.ChoiceLabels[choice] = text.In(lang)
+		}
+	}
+	if !p.TipText.IsZero() {
+		p.Tip = p.TipText.In(lang)
+	}
+}
+
+// Text returns the tip's explanation.
+func (t Tip) Text() Text {
+	switch t {
+	case TipAmmoOnShotgun:
+		return NewText(MsgTipAmmoOnShotgun)
+	case TipHayWall:
+		return NewText(MsgTipHayWall)
+	case TipShield:
+		return NewText(MsgTipShield)
+	case TipEvent:
+		return NewText(MsgTipEvent)
+	default:
+		return Text{}
+	}
+}
+
+// eventKey returns the catalog key of the event's description.
+func eventKey(name string) MessageKey {
+	return MessageKey("event." + strings.ToLower(strings.ReplaceAll(name, " ", "-")))
+}
+
+// DescriptionText returns the event's description.
+func (e Event) DescriptionText() Text {
+	return NewText(eventKey(e.Name))
+}
+
+// StageText describes what the current player does in a stage of their turn.
+// The rules give the number of cards played and drawn.
+func StageText(s StageInTurn, rules Rules) Text {
+	switch s {
+	case OptionalDiscard:
+		return NewText(MsgStageDiscard)
+	case Play2Cards:
+		return NewText(MsgStagePlay, rules.PlaysPerTurn)
+	case Draw2Cards:
+		return NewText(MsgStageDraw, rules.DrawsPerTurn, rules.PublicCards)
+	case ShareCards:
+		return NewText(MsgStageShare)
+	case ChooseHand:
+		return NewText(MsgStageChooseHand)
+	case Nighttime:
+		return NewText(MsgStageNight)
+	default:
+		return Text{}
+	}
+}
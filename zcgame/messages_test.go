@@ -0,0 +1,38 @@
+package zcgame
+
+import "testing"
+
+func TestMessages(t *testing.T) {
+	for lang, messages := range catalog {
+		for key := range catalog[English] {
+			if _, ok := messages[key]; !ok {
+				t.Errorf("%s catalog is missing %q", lang, key)
+			}
+		}
+	}
+
+	view, err := CreateNewGameWithOptions(GameOptions{Seed: 9}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	_, input := view.ContinueDay()
+	if input.Message != "Discard a card to draw a replacement, or skip" {
+		t.Fatalf("expected the English prompt by default, got %q", input.Message)
+	}
+	spanish := input.Localized(Spanish)
+	if spanish.Message == input.Message || input.Localized(English).Message != input.Message {
+		t.Fatalf("expected only the Spanish prompt to differ, got %q", spanish.Message)
+	}
+
+	for header, want := range map[string]Language{
+		"":                        English,
+		"es-MX,es;q=0.9,en;q=0.8": Spanish,
+		"en-US,en;q=0.9,es;q=0.8": English,
+		"fr-FR,fr;q=0.9,es;q=0.5": Spanish,
+		"de":                      English,
+	} {
+		if got := MatchLanguage(header); got != want {
+			t.Errorf("MatchLanguage(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
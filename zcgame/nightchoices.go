@@ -0,0 +1,114 @@
+package zcgame
+
+// Night Choices
+//
+// PlayerNightChoices lets a player answer the repetitive night prompts ahead of
+// time. Before a prompt is handed to the frontend, the game checks the prompted
+// player's choices and answers it itself when they say how. The zero value asks
+// every time, like a game without preferences.
+
+import "fmt"
+
+// ShieldChoice decides how the Shield prompt against an Exploding zombie is answered.
+type ShieldChoice uint8
+
+const (
+	ShieldAsk    ShieldChoice = iota // Ask every time
+	ShieldAlways                     // Always spend a Shield to save the stack
+	ShieldNever                      // Never spend a Shield; the stack is destroyed
+)
+
+// ParseShieldChoice parses the String form of a ShieldChoice.
+func ParseShieldChoice(s string) (ShieldChoice, error) {
+	for choice := ShieldAsk; choice <= ShieldNever; choice++ {
+		if choice.String() == s {
+			return choice, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown shield choice %q (want ask, always or never)", s)
+}
+
+// PlayerNightChoices configures automatic answers to a player's night prompts.
+type PlayerNightChoices struct {
+	// AutoConfirm skips the player's night confirmations: auto-kills, life
+	// losses, events and boss arrivals are resolved without pressing continue.
+	AutoConfirm bool
+
+	// AutoDefend uses the cheapest stack that beats the zombie instead of asking.
+	// A stack's cost is the cards it loses; ties go to the first stack. The
+	// player is still asked if no stack can help, and against bosses.
+	AutoDefend bool
+
+	// Shield answers the Shield prompt against Exploding zombies.
+	Shield ShieldChoice
+
+	// SpareWOLR keeps AutoDefend from ever picking a W.O.L.R.; the player is
+	// asked instead when it is the only defense.
+	SpareWOLR bool
+}
+
+// nightAutoChoice returns the choice the prompted player's night choices make
+// for the input, if they make one.
+func (g *gameState) nightAutoChoice(input *PlayerInputNeeded) (int, bool) {
+	if input == nil || g.Turn != Night || len(g.Players) == 0 {
+		return 0, false
+	}
+	player := g.CurrentPlayer()
+	choices := player.NightChoices
+
+	switch input.Context {
+	case InputContextConfirm:
+		return 0, choices.AutoConfirm
+
+	case InputContextShield:
+		switch choices.Shield {
+		case ShieldAlways:
+			return 1, true
+		case ShieldNever:
+			return 0, true
+		}
+
+	case InputContextDefense:
+		if !choices.AutoDefend || g.NightSubStage != NightSubStageChooseDefense {
+			return 0, false
+		}
+		return g.cheapestDefense(input, choices.SpareWOLR)
+	}
+	return 0, false
+}
+
+// cheapestDefense returns the 1-based choice of the defense prompt's stack that
+// costs the current player the fewest cards; ties go to the first stack.
+// Returns false if no stack can help, or only a W.O.L.R. and spareWOLR is set.
+func (g *gameState) cheapestDefense(input *PlayerInputNeeded, spareWOLR bool) (int, bool) {
+	player := g.CurrentPlayer()
+	best, bestCost := -1, 0
+	for _, idx := range input.ValidStacks {
+		if spareWOLR && player.Farm.Stacks[idx].HasItem(WOLR) {
+			continue
+		}
+		if cost := player.Farm.defenseCost(idx, *g.CurrentZombie); best == -1 || cost < bestCost {
+			best, bestCost = idx, cost
+		}
+	}
+	return best + 1, best != -1
+}
+
+// defenseCost returns how many cards the farm loses if the stack at stackIdx is
+// used against the zombie.
+func (f *Farm) defenseCost(stackIdx int, zc ZombieChicken) int {
+	stack := f.Stacks[stackIdx]
+	if stack.HasItem(WOLR) {
+		return f.Stacks.TotalItems()
+	}
+	if zc.IsBoss() || zc.Traits.HasTrait(Exploding) {
+		// The whole stack is destroyed
+		return len(stack)
+	}
+	for _, item := range []FarmItemType{Ammo, BoobyTrap, Pitchfork, WornPitchfork} {
+		if stack.HasItem(item) {
+			return 1
+		}
+	}
+	return 0
+}
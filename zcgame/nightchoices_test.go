@@ -0,0 +1,40 @@
+package zcgame
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNightChoices(t *testing.T) {
+	for range 20 {
+		view, err := CreateNewGameWithOptions(GameOptions{MaxNights: 8}, "p1", "p2")
+		if err != nil {
+			t.Fatalf("failed to create game: %v", err)
+		}
+		choices := PlayerNightChoices{AutoConfirm: true, AutoDefend: true, Shield: ShieldNever}
+		for i := range view.PlayerCount() {
+			view.SetNightChoices(i, choices)
+		}
+
+		rng := rand.New(rand.NewSource(view.game.Seed))
+		cont, input := view.ContinueDay()
+		for steps := 0; cont || input != nil; steps++ {
+			if steps > 100000 {
+				t.Fatal("game did not end")
+			}
+			if input == nil {
+				cont, input = view.ContinueDay()
+				continue
+			}
+			if view.Turn() == Night {
+				switch {
+				case input.Context == InputContextConfirm, input.Context == InputContextShield:
+					t.Fatalf("expected %q to be answered automatically", input.Message)
+				case input.Context == InputContextDefense && view.game.NightSubStage == NightSubStageChooseDefense:
+					t.Fatalf("expected the defense for %q to be chosen automatically", input.Message)
+				}
+			}
+			cont, input = view.ContinueAfterInput(randomChoice(rng, input))
+		}
+	}
+}
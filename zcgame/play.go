@@ -1,5 +1,7 @@
 package zcgame
 
+import "slices"
+
 // countItemInStack returns the count of a specific item type within a stack.
 func countItemInStack(stack Stack, item FarmItemType) int {
 	count := 0
@@ -54,7 +56,7 @@ func (f *Farm) PlayCard(item FarmItemType, choices PlayerPlayChoices) *PlayCardR
 	}
 
 	switch item {
-	case Scarecrow, BoobyTrap, Shield, WOLR:
+	case Scarecrow, BoobyTrap, Shield, WOLR, Pitchfork, GuardDog, Bait:
 		// Simple items - always new stack
 		f.makeStackWith(item)
 	case Flamethrower:
@@ -87,6 +89,36 @@ func (f *Farm) PlayCard(item FarmItemType, choices PlayerPlayChoices) *PlayCardR
 		} else {
 			f.makeStackWith(Fuel)
 		}
+	case ElectricFence:
+		// Find generator-only stacks (has Generator, no Electric Fence)
+		generatorStacks := f.findStacks(Stack{Generator})
+		generatorOnlyStacks := []int{}
+		for _, idx := range generatorStacks {
+			if !f.Stacks[idx].HasItem(ElectricFence) {
+				generatorOnlyStacks = append(generatorOnlyStacks, idx)
+			}
+		}
+		if len(generatorOnlyStacks) > 0 {
+			// Add to first unpaired Generator
+			f.Stacks[generatorOnlyStacks[0]] = append(f.Stacks[generatorOnlyStacks[0]], ElectricFence)
+		} else {
+			f.makeStackWith(ElectricFence)
+		}
+	case Generator:
+		// Find electric fence stacks without a Generator
+		fenceStacks := f.findStacks(Stack{ElectricFence})
+		unpoweredStacks := []int{}
+		for _, idx := range fenceStacks {
+			if !f.Stacks[idx].HasItem(Generator) {
+				unpoweredStacks = append(unpoweredStacks, idx)
+			}
+		}
+		if len(unpoweredStacks) > 0 {
+			// Add to first unpowered Electric Fence
+			f.Stacks[unpoweredStacks[0]] = append(f.Stacks[unpoweredStacks[0]], Generator)
+		} else {
+			f.makeStackWith(Generator)
+		}
 	case Shotgun:
 		// Find ammo-only stacks (has Ammo, no Shotgun)
 		ammoStacks := f.findStacks(Stack{Ammo})
@@ -252,6 +284,26 @@ const (
 	InputContextShield                           // Night: deciding whether to use Shield
 	InputContextConfirm                          // Confirmation prompt (press 0 to continue)
 	InputContextEventDiscard                     // Selecting cards to discard during an event
+	InputContextGive                             // Co-op: selecting a card from hand to give to a teammate (0 to skip)
+	InputContextChoosePlayer                     // Choosing another player; see ChoiceLabels
+	InputContextLend                             // Co-op night: a neighbor selecting a stack to lend (0 to refuse)
+	InputContextSabotage                         // Selecting an item on another player's farm to discard
+	InputContextTrade                            // Selecting a card from hand to trade with another player
+	InputContextMulligan                         // Setup: keeping or redrawing the opening hand; see ChoiceLabels
+	InputContextDraft                            // Setup: picking a card from the draft pool; see ChoiceLabels
+	InputContextMove                             // Selecting a card on your farm to move to another stack
+)
+
+// StackChoiceCancel is offered when placing a card from hand or moving a farm
+// card (InputContextPlayCard): the card goes back where it came from and the
+// player picks again.
+const StackChoiceCancel = -1
+
+// Choices for InputContextDraw.
+const (
+	DrawChoicePublic = 1 // Take the face-up cards, and any draws beyond them from the deck
+	DrawChoiceDeck   = 2 // Draw Rules.DrawsPerTurn cards from the deck
+	DrawChoiceMixed  = 3 // Scavenger: take one face-up card and draw the rest from the deck
 )
 
 // RenderType specifies which rendering mode to use when displaying the game state.
@@ -283,12 +335,19 @@ const (
 type PlayerInputNeeded struct {
 	Context      InputContext
 	RenderType   RenderType
-	Message      string // human-readable prompt
+	Message      string // human-readable prompt, in English; see Localized
+	Text         Text   // the prompt as a catalog message
 	ValidChoices []int  // valid input values
 
 	// Optional context for specific input types
-	Item        FarmItemType // for InputContextPlayCard - which item needs placement
-	ValidStacks []int        // for InputContextPlayCard/InputContextDefense - valid stack indices
+	Item          FarmItemType   // for InputContextPlayCard - which item needs placement
+	ValidStacks   []int          // for InputContextPlayCard/InputContextDefense/InputContextLend - valid stack indices
+	ChoiceLabels  map[int]string // for InputContextChoosePlayer/InputContextDraw - display label for each choice, in English
+	LabelTexts    map[int]Text   // ChoiceLabels as catalog messages
+	SelectCount   int            // for InputContextEventDiscard - how many choices may be picked at once with ContinueAfterInputs
+	DefaultChoice int            // the cautious answer to submit for a player who doesn't answer in time; always one of ValidChoices
+	Tip           string         // Tutorial: explanation of a rule that just came up, shown with the prompt, in English
+	TipText       Text           // Tip as a catalog message
 }
 
 // Error implements the error interface. PlayerInputNeeded is used as a signal type
@@ -297,6 +356,20 @@ func (e *PlayerInputNeeded) Error() string {
 	return "needs player input: " + e.Message
 }
 
+// ValidSelection returns true if choices can answer the prompt in one go with
+// ContinueAfterInputs: exactly SelectCount distinct values from ValidChoices.
+func (e *PlayerInputNeeded) ValidSelection(choices []int) bool {
+	if e.SelectCount == 0 || len(choices) != e.SelectCount {
+		return false
+	}
+	for i, choice := range choices {
+		if !slices.Contains(e.ValidChoices, choice) || slices.Contains(choices[:i], choice) {
+			return false
+		}
+	}
+	return true
+}
+
 // addToStackIndex adds item to f.Stacks[stackIndex]. Only called internally by the state machine
 // with pre-validated indices from ValidChoices, so invalid indices indicate a bug and are ignored.
 func (f *Farm) addToStackIndex(item FarmItemType, stackIndex int) {
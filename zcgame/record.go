@@ -0,0 +1,490 @@
+package zcgame
+
+// Game Records
+//
+// Every answer given to a prompt is kept as a Move, so a finished (or
+// unfinished) game can be written out as a Record: a header of tags naming the
+// players, seed and rules, then one line per move, much like a chess PGN.
+//
+//	[Player "Alice"]
+//	[Player "Bob"]
+//	[Seed "42"]
+//	[Mode "competitive"]
+//	...
+//
+//	M1 P1 Alice plays Shotgun = 3
+//	N2 P1 Alice defends Biter with stack 2 (Shotgun, Ammo) = 2
+//
+// A move line starts with the turn and night number (S for Setup, M for
+// Morning, A for Afternoon, D for the co-op Day, N for Night) and the PlayerID
+// of the player who answered, and ends with the choice they gave. The text in
+// between is for people reading the record; ParseRecord ignores it.
+//
+// The same seed and options deal the same cards, so Replay rebuilds the game
+// by answering every prompt with the recorded choice. Night prompts answered
+// automatically by PlayerNightChoices are recorded like any other, so a replay
+// doesn't need the night choices the game was played with. Undone decisions
+// are dropped from the record.
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Move is one answer to a prompt.
+type Move struct {
+	Turn     Turn     // Turn the prompt came up in
+	NightNum int      // Night number at the time
+	PlayerID PlayerID // Player who answered
+	Text     string   // What the player did, starting with their name
+	Choice   int      // The choice given
+}
+
+// Record is everything needed to replay a game.
+type Record struct {
+	Date    string      // Day the record was written, YYYY-MM-DD
+	Players []string    // Player names in seat order
+	Seed    int64       // Seed the game was dealt with
+	Options GameOptions // Rules the game was played with; Options.Seed is ignored
+	Level   string      // ID of the campaign level or tutorial played, empty for other games
+	Debug   bool        // The night deck was put in debug order (see DebugMode)
+	Result  string      // Why the game ended, or "*" if it is still going
+	Moves   []Move
+}
+
+// turnLetters are the move line prefixes of each Turn.
+var turnLetters = map[Turn]string{Setup: "S", Morning: "M", Afternoon: "A", Day: "D", Night: "N"}
+
+func (m Move) String() string {
+	return fmt.Sprintf("%s%d P%d %s = %d", turnLetters[m.Turn], m.NightNum, m.PlayerID, m.Text, m.Choice)
+}
+
+// recordMove adds the answer to the waiting prompt to the game's moves. The
+// prompt is the one in lastInput, answered by activeInputPlayerIdx.
+func (g *gameState) recordMove(choice int) {
+	if g.lastInput == nil || len(g.Players) == 0 {
+		return
+	}
+	player := g.Players[g.activeInputPlayerIdx()]
+	g.Moves = append(g.Moves, Move{
+		Turn:     g.Turn,
+		NightNum: g.NightNum,
+		PlayerID: player.ID,
+		Text:     player.Name + " " + g.describeMove(g.lastInput, player, choice),
+		Choice:   choice,
+	})
+}
+
+// describeMove says in English what the player does by giving choice to input.
+// Called before the choice is applied.
+func (g *gameState) describeMove(input *PlayerInputNeeded, player *Player, choice int) string {
+	handCard := func() FarmItemType {
+		if choice < 1 || choice > len(player.Hand) {
+			return NUM_FARM_ITEMS
+		}
+		return player.Hand[choice-1].FarmItemType
+	}
+	stack := func(farm *Farm) string {
+		if choice < 1 || choice > len(farm.Stacks) {
+			return fmt.Sprintf("stack %d", choice)
+		}
+		return fmt.Sprintf("stack %d (%s)", choice, stackNames(farm.Stacks[choice-1]))
+	}
+	zombie := "the zombie"
+	if g.CurrentZombie != nil {
+		zombie = g.CurrentZombie.Name
+	}
+
+	switch input.Context {
+	case InputContextDiscard:
+		if g.Turn == Day && g.DaySubStage == DaySubStageGiveRoom {
+			if choice == 0 {
+				return "refuses " + input.Item.String()
+			}
+			return fmt.Sprintf("discards %s to take %s", handCard(), input.Item)
+		}
+		if choice == 0 {
+			return "keeps their hand"
+		}
+		return "discards " + handCard().String()
+	case InputContextPlay:
+		if choice == PlayChoiceMove {
+			return "moves a card on their farm"
+		}
+		return "plays " + handCard().String()
+	case InputContextPlayCard:
+		switch choice {
+		case 0:
+			return fmt.Sprintf("puts %s on a new stack", input.Item)
+		case StackChoiceCancel:
+			return fmt.Sprintf("takes back %s", input.Item)
+		default:
+			return fmt.Sprintf("puts %s on %s", input.Item, stack(g.Players[g.stackChoicePlayerIdx()].Farm))
+		}
+	case InputContextDefense:
+		if choice == -1 {
+			return "takes the hit from " + zombie
+		}
+		return fmt.Sprintf("defends %s with %s", zombie, stack(player.Farm))
+	case InputContextShield:
+		if choice == 1 {
+			return "uses a Shield"
+		}
+		return "keeps their Shield"
+	case InputContextConfirm:
+		return "sees " + strconv.Quote(strings.TrimPrefix(input.Message, player.Name+": "))
+	case InputContextEventDiscard, InputContextMove:
+		verb := "discards "
+		if input.Context == InputContextMove {
+			verb = "picks up "
+		}
+		return verb + flatItem(player.Farm.Stacks, choice).String()
+	case InputContextGive:
+		if choice == 0 {
+			return "gives nothing"
+		}
+		return "gives " + handCard().String()
+	case InputContextLend:
+		if choice == 0 {
+			return "refuses to lend a stack"
+		}
+		return "lends " + stack(player.Farm)
+	case InputContextSabotage:
+		return "sabotages " + flatItem(g.Players[g.stackChoicePlayerIdx()].Farm.Stacks, choice).String()
+	case InputContextTrade:
+		return "trades " + handCard().String()
+	}
+	if label, ok := input.ChoiceLabels[choice]; ok {
+		return "chooses " + strconv.Quote(label)
+	}
+	return fmt.Sprintf("chooses %d", choice)
+}
+
+// stackNames lists a stack's cards, e.g. "Shotgun, Ammo".
+func stackNames(stack Stack) string {
+	names := make([]string, len(stack))
+	for i, item := range stack {
+		names[i] = item.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// flatItem returns the farm item at a 1-based flat index across all stacks,
+// or NUM_FARM_ITEMS if there is none.
+func flatItem(stacks Stacks, flatIdx int) FarmItemType {
+	idx := 1
+	for _, stack := range stacks {
+		for _, item := range stack {
+			if idx == flatIdx {
+				return item
+			}
+			idx++
+		}
+	}
+	return NUM_FARM_ITEMS
+}
+
+// Record returns the game's record so far.
+func (v GameView) Record() Record {
+	g := v.game
+	seats := slices.Concat(g.Players, g.Eliminated)
+	slices.SortFunc(seats, func(a, b *Player) int { return int(a.ID) - int(b.ID) })
+	players := make([]string, len(seats))
+	for i, player := range seats {
+		players[i] = player.Name
+	}
+
+	record := Record{
+		Date:    time.Now().Format(time.DateOnly),
+		Players: players,
+		Seed:    g.Seed,
+		Options: v.Options(),
+		Debug:   g.DebugDeck,
+		Result:  "*",
+		Moves:   slices.Clone(g.Moves),
+	}
+	if g.Level != nil {
+		record.Level = g.Level.ID
+	}
+	if g.EndReason != GameNotOver {
+		record.Result = g.EndReason.String()
+	}
+	return record
+}
+
+// String writes the record out in the notation ParseRecord reads.
+func (r Record) String() string {
+	var b strings.Builder
+	tag := func(name string, value any) {
+		fmt.Fprintf(&b, "[%s %s]\n", name, strconv.Quote(fmt.Sprint(value)))
+	}
+	flag := func(name string, on bool) {
+		if on {
+			tag(name, "on")
+		}
+	}
+
+	tag("Date", r.Date)
+	for _, name := range r.Players {
+		tag("Player", name)
+	}
+	tag("Seed", r.Seed)
+	if r.Level != "" {
+		tag("Level", r.Level)
+	}
+	options := r.Options
+	tag("Mode", options.Mode)
+	flag("Expansion", options.DefenseExpansion)
+	flag("Zombies", options.ZombieExpansion)
+	flag("Interaction", options.InteractionCards)
+	flag("Roles", options.Roles)
+	flag("Reorganize", options.Reorganize)
+	flag("Tutorial", options.Tutorial)
+	flag("SimultaneousDiscards", options.SimultaneousDiscards)
+	if options.BossEvery > 0 {
+		tag("Bosses", options.BossEvery)
+	}
+	tag("Exhaust", options.DeckExhaustion)
+	if options.MaxNights > 0 {
+		tag("MaxNights", options.MaxNights)
+	}
+	if options.CoopNights > 0 {
+		tag("CoopNights", options.CoopNights)
+	}
+	if options.Teams != nil {
+		teams := make([]string, len(options.Teams))
+		for i, team := range options.Teams {
+			teams[i] = strconv.Itoa(team + 1)
+		}
+		tag("Teams", strings.Join(teams, ","))
+	}
+	tag("Setup", options.Setup)
+	rules := options.Rules.WithDefaults()
+	tag("HandSize", rules.HandSize)
+	tag("Plays", rules.PlaysPerTurn)
+	tag("Draws", rules.DrawsPerTurn)
+	tag("FaceUp", rules.PublicCards)
+	if rules.StartingLives > 0 {
+		tag("Lives", rules.StartingLives)
+	}
+	tag("NightCards", rules.NightCards)
+	flag("Debug", r.Debug)
+	tag("Result", r.Result)
+
+	b.WriteString("\n")
+	for _, move := range r.Moves {
+		b.WriteString(move.String() + "\n")
+	}
+	return b.String()
+}
+
+// ParseRecord reads a record written by Record.String. Blank lines and lines
+// starting with ';' are skipped, as are tags it doesn't know.
+func ParseRecord(reader io.Reader) (Record, error) {
+	r := Record{Result: "*"}
+	scanner := bufio.NewScanner(reader)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		var err error
+		switch {
+		case line == "" || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			err = r.parseTag(line)
+		default:
+			var move Move
+			move, err = parseMove(line)
+			r.Moves = append(r.Moves, move)
+		}
+		if err != nil {
+			return Record{}, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Record{}, err
+	}
+	if len(r.Players) == 0 {
+		return Record{}, fmt.Errorf("record has no players")
+	}
+	return r, nil
+}
+
+// parseTag reads a header line such as [Seed "42"] into the record.
+func (r *Record) parseTag(line string) error {
+	name, quoted, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"), " ")
+	if !ok || !strings.HasSuffix(line, "]") {
+		return fmt.Errorf("invalid tag %q", line)
+	}
+	value, err := strconv.Unquote(quoted)
+	if err != nil {
+		return fmt.Errorf("invalid %s tag value %s", name, quoted)
+	}
+	number := func(dest *int) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid %s %q", name, value)
+		}
+		*dest = n
+		return nil
+	}
+
+	options := &r.Options
+	switch name {
+	case "Date":
+		r.Date = value
+	case "Player":
+		r.Players = append(r.Players, value)
+	case "Seed":
+		r.Seed, err = strconv.ParseInt(value, 10, 64)
+	case "Level":
+		r.Level = value
+	case "Mode":
+		options.Mode, err = ParseGameMode(value)
+	case "Expansion":
+		options.DefenseExpansion = value == "on"
+	case "Zombies":
+		options.ZombieExpansion = value == "on"
+	case "Interaction":
+		options.InteractionCards = value == "on"
+	case "Roles":
+		options.Roles = value == "on"
+	case "Reorganize":
+		options.Reorganize = value == "on"
+	case "Tutorial":
+		options.Tutorial = value == "on"
+	case "SimultaneousDiscards":
+		options.SimultaneousDiscards = value == "on"
+	case "Bosses":
+		err = number(&options.BossEvery)
+	case "Exhaust":
+		options.DeckExhaustion, err = ParseDeckExhaustionRule(value)
+	case "MaxNights":
+		err = number(&options.MaxNights)
+	case "CoopNights":
+		err = number(&options.CoopNights)
+	case "Teams":
+		options.Teams, err = ParseTeams(value)
+	case "Setup":
+		options.Setup, err = ParseSetupVariant(value)
+	case "HandSize":
+		err = number(&options.Rules.HandSize)
+	case "Plays":
+		err = number(&options.Rules.PlaysPerTurn)
+	case "Draws":
+		err = number(&options.Rules.DrawsPerTurn)
+	case "FaceUp":
+		err = number(&options.Rules.PublicCards)
+	case "Lives":
+		err = number(&options.Rules.StartingLives)
+	case "NightCards":
+		options.Rules.NightCards, err = ParseNightCardCurve(value)
+	case "Debug":
+		r.Debug = value == "on"
+	case "Result":
+		r.Result = value
+	}
+	return err
+}
+
+// parseMove reads a move line such as "N2 P1 Alice takes the hit from Biter = -1".
+func parseMove(line string) (Move, error) {
+	fields := strings.Fields(line)
+	sep := strings.LastIndex(line, " = ")
+	if len(fields) < 3 || sep == -1 {
+		return Move{}, fmt.Errorf("invalid move %q", line)
+	}
+
+	var move Move
+	tag := fields[0]
+	for turn, letter := range turnLetters {
+		if num, ok := strings.CutPrefix(tag, letter); ok {
+			night, err := strconv.Atoi(num)
+			if err != nil {
+				return Move{}, fmt.Errorf("invalid turn %q", tag)
+			}
+			move.Turn, move.NightNum = turn, night
+			break
+		}
+	}
+	if move.NightNum == 0 {
+		return Move{}, fmt.Errorf("invalid turn %q", tag)
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(fields[1], "P"))
+	if err != nil || !strings.HasPrefix(fields[1], "P") {
+		return Move{}, fmt.Errorf("invalid player %q", fields[1])
+	}
+	move.PlayerID = PlayerID(id)
+	move.Choice, err = strconv.Atoi(strings.TrimSpace(line[sep+3:]))
+	if err != nil {
+		return Move{}, fmt.Errorf("invalid choice in %q", line)
+	}
+	start := len(tag) + 1 + len(fields[1]) + 1
+	if start <= sep {
+		move.Text = strings.TrimSpace(line[start:sep])
+	}
+	return move, nil
+}
+
+// Replay creates the recorded game and answers its prompts with the recorded
+// moves. onMove, if not nil, is called with the game as it is before each
+// move is made. Returns the game after the last move, which may still be
+// waiting for input (see PendingInputs), or an error if a move doesn't fit the
+// game, for example because the record was edited.
+func (r Record) Replay(onMove func(GameView, Move)) (GameView, error) {
+	view, err := r.newGame()
+	if err != nil {
+		return GameView{}, err
+	}
+
+	cont, input := view.ContinueDay()
+	for i, move := range r.Moves {
+		for input == nil && cont {
+			cont, input = view.ContinueDay()
+		}
+		if input == nil {
+			return view, fmt.Errorf("move %d: the game is already over", i+1)
+		}
+		playerIdx := view.PlayerIdxByID(move.PlayerID)
+		pending, ok := view.PendingInputs()[playerIdx]
+		switch {
+		case view.Turn() != move.Turn || view.NightNum() != move.NightNum:
+			return view, fmt.Errorf("move %d: recorded on %s %d, but the game is on %s %d", i+1, move.Turn, move.NightNum, view.Turn(), view.NightNum())
+		case !ok:
+			return view, fmt.Errorf("move %d: P%d isn't being asked anything", i+1, move.PlayerID)
+		case !slices.Contains(pending.ValidChoices, move.Choice):
+			return view, fmt.Errorf("move %d: %d isn't a valid answer to %q", i+1, move.Choice, pending.Message)
+		}
+		if onMove != nil {
+			onMove(view, move)
+		}
+		cont, input = view.ContinueAfterInputFrom(playerIdx, move.Choice)
+	}
+	for input == nil && cont {
+		cont, input = view.ContinueDay()
+	}
+	return view, nil
+}
+
+// newGame creates the recorded game, dealt as it was when it was played.
+func (r Record) newGame() (GameView, error) {
+	defer func(debug bool) { DebugMode = debug }(DebugMode)
+	DebugMode = r.Debug
+
+	if r.Level == "" {
+		options := r.Options
+		options.Seed = r.Seed
+		return CreateNewGameWithOptions(options, r.Players...)
+	}
+	for _, level := range append([]Level{TutorialLevel}, Campaign...) {
+		if level.ID == r.Level {
+			level.Options.Seed = r.Seed
+			return CreateLevelGame(level, r.Players[0])
+		}
+	}
+	return GameView{}, fmt.Errorf("unknown level %q", r.Level)
+}
@@ -0,0 +1,63 @@
+package zcgame
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	for _, options := range []GameOptions{
+		{},
+		{Mode: ModeCoop, Roles: true, Setup: SetupMulligan},
+		{BossEvery: 2, SimultaneousDiscards: true, Setup: SetupDraft, InteractionCards: true, ZombieExpansion: true},
+	} {
+		view, err := CreateNewGameWithOptions(options, "p1", "p2", "p3")
+		if err != nil {
+			t.Fatalf("failed to create game: %v", err)
+		}
+		view.SetNightChoices(0, PlayerNightChoices{AutoConfirm: true, AutoDefend: true})
+		playGame(t, view.game)
+
+		text := view.Record().String()
+		record, err := ParseRecord(strings.NewReader(text))
+		if err != nil {
+			t.Fatalf("failed to parse record: %v\n%s", err, text)
+		}
+		replayed, err := record.Replay(nil)
+		if err != nil {
+			t.Fatalf("failed to replay %s game: %v", options.Mode, err)
+		}
+		if replayed.EndReason() != view.EndReason() || replayed.Record().String() != text {
+			t.Fatalf("replayed %s game doesn't match:\n%s", options.Mode, replayed.Record())
+		}
+
+		// A record cut short stops at the prompt it got to
+		record.Moves = record.Moves[:20]
+		replayed, err = record.Replay(nil)
+		if err != nil || len(replayed.PendingInputs()) == 0 {
+			t.Fatalf("expected a short record to stop at a prompt, got %v", err)
+		}
+		record.Moves[19].Choice = 99
+		if _, err := record.Replay(nil); err == nil {
+			t.Fatal("expected an invalid move to fail the replay")
+		}
+	}
+
+	// An undone move leaves the record
+	view, err := CreateNewGameWithOptions(GameOptions{Seed: 5}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	view.ContinueDay()
+	view.ContinueAfterInput(0) // Skip the discard
+	view.Undo()
+	view.ContinueAfterInput(1)
+	moves := view.Record().Moves
+	if len(moves) != 1 || moves[0].Choice != 1 {
+		t.Fatalf("expected only the discard after the undo, got %v", moves)
+	}
+	replayed, err := view.Record().Replay(nil)
+	if err != nil || replayed.Record().String() != view.Record().String() {
+		t.Fatalf("replayed game after an undo doesn't match: %v\n%s", err, replayed.Record())
+	}
+}
@@ -0,0 +1,200 @@
+package zcgame
+
+// Reorganizing the Farm
+//
+// With GameOptions.Reorganize, instead of playing a card from hand, a player
+// may spend one of their plays moving a card from one stack on their farm to
+// another, or to a stack of its own. Moves follow the same stacking rules as
+// played cards (validateStack), so a Shotgun can join a pile of Ammo and Ammo
+// can be split between two Shotguns, but a Hay Bale can't join a Scarecrow.
+//
+// The move is offered as PlayChoiceMove on the play prompt. The player then
+// picks the card with InputContextMove and its new stack with
+// InputContextPlayCard, like placing a card from hand.
+
+import "slices"
+
+// PlayChoiceMove is offered on the InputContextPlay prompt when
+// GameOptions.Reorganize is on and a card on the player's farm can be moved to
+// another stack.
+const PlayChoiceMove = -2
+
+// startMove spends the current play on moving a card. The turn moves on to
+// the next play once the card is in its new stack.
+func (g *gameState) startMove() *PlayerInputNeeded {
+	g.ActionPlayStage = g.DaySubStage
+	g.DaySubStage = DaySubStageMoveCard
+	return g.doPlayerDayTurn()
+}
+
+// createMoveCardInput asks which card on the farm to move.
+func (g *gameState) createMoveCardInput() *PlayerInputNeeded {
+	return &PlayerInputNeeded{
+		Context:      InputContextMove,
+		RenderType:   RenderForDiscard,
+		Text:         NewText(MsgMoveCard),
+		ValidChoices: g.CurrentPlayer().Farm.movableItems(),
+	}
+}
+
+// createMoveStackInput asks which stack the card being moved goes to.
+func (g *gameState) createMoveStackInput() *PlayerInputNeeded {
+	stacks, newStack := g.CurrentPlayer().Farm.moveTargets(g.PendingCardItem, g.MoveFromStack)
+	choices := []int{}
+	if newStack {
+		choices = append(choices, 0)
+	}
+	for _, idx := range stacks {
+		choices = append(choices, idx+1)
+	}
+	choices = append(choices, StackChoiceCancel)
+	return &PlayerInputNeeded{
+		Context:      InputContextPlayCard,
+		RenderType:   RenderNormal,
+		Text:         NewText(MsgMoveStack, g.PendingCardItem),
+		ValidChoices: choices,
+		Item:         g.PendingCardItem,
+		ValidStacks:  stacks,
+		LabelTexts:   map[int]Text{StackChoiceCancel: NewText(MsgMoveCancel)},
+	}
+}
+
+// provideMoveInput handles input while moving a card between stacks.
+func (g *gameState) provideMoveInput(choice int) *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+
+	switch g.DaySubStage {
+	case DaySubStageMoveCard:
+		g.MoveFromIndex = choice - 1
+		g.PendingCardItem, g.MoveFromStack = player.Farm.takeItemByFlatIndex(g.MoveFromIndex)
+		g.DaySubStage = DaySubStageMoveStack
+		return g.doPlayerDayTurn()
+
+	case DaySubStageMoveStack:
+		if choice == StackChoiceCancel {
+			// Put the card back and return to choosing a card to play
+			player.Farm.putBack(g.PendingCardItem, g.MoveFromIndex, g.MoveFromStack)
+			g.DaySubStage = g.ActionPlayStage
+			return g.doPlayerDayTurn()
+		}
+		if choice == 0 {
+			player.Farm.makeStackWith(g.PendingCardItem)
+		} else {
+			player.Farm.addToStackIndex(g.PendingCardItem, choice-1)
+		}
+	}
+
+	g.finishPlay()
+	return g.doPlayerDayTurn()
+}
+
+// canReorganize returns true if any item on the farm can be moved.
+func (f *Farm) canReorganize() bool {
+	for i, stack := range f.Stacks {
+		for _, item := range stack {
+			if f.canMove(i, item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// movableItems returns the 1-based flat indices of the farm items that can be
+// moved somewhere other than where they are.
+func (f *Farm) movableItems() []int {
+	choices := []int{}
+	flatIdx := 0
+	for i, stack := range f.Stacks {
+		movable := map[FarmItemType]bool{}
+		for _, item := range stack {
+			flatIdx++
+			canMove, checked := movable[item]
+			if !checked {
+				canMove = f.canMove(i, item)
+				movable[item] = canMove
+			}
+			if canMove {
+				choices = append(choices, flatIdx)
+			}
+		}
+	}
+	return choices
+}
+
+// canMove returns true if one item can be taken from the stack at stackIdx,
+// leaving a legal stack behind, and put somewhere else.
+func (f *Farm) canMove(stackIdx int, item FarmItemType) bool {
+	fromStack := -1
+	if len(f.Stacks[stackIdx]) > 1 {
+		left := stackCounts(f.Stacks[stackIdx])
+		left[item]--
+		if left[item] == 0 {
+			delete(left, item)
+		}
+		if validateStack(stackIdx, left) != nil {
+			return false
+		}
+		fromStack = stackIdx
+	}
+	if fromStack != -1 && validateStack(len(f.Stacks), map[FarmItemType]int{item: 1}) == nil {
+		return true
+	}
+	for i, stack := range f.Stacks {
+		if i == stackIdx {
+			continue
+		}
+		counts := stackCounts(stack)
+		counts[item]++
+		if validateStack(i, counts) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// moveTargets returns the stacks a card taken from fromStack may be moved to,
+// and whether it may start a new stack. fromStack is -1 if taking the card
+// left its stack empty, in which case a new stack would just put it back.
+func (f *Farm) moveTargets(item FarmItemType, fromStack int) ([]int, bool) {
+	stacks := []int{}
+	for i, stack := range f.Stacks {
+		if i == fromStack {
+			continue
+		}
+		counts := stackCounts(stack)
+		counts[item]++
+		if validateStack(i, counts) == nil {
+			stacks = append(stacks, i)
+		}
+	}
+	newStack := fromStack != -1 && validateStack(len(f.Stacks), map[FarmItemType]int{item: 1}) == nil
+	return stacks, newStack
+}
+
+// putBack returns an item taken with takeItemByFlatIndex to where it was.
+// fromStack is the stack it was taken from, or -1 if that left the stack empty.
+func (f *Farm) putBack(item FarmItemType, flatIdx, fromStack int) {
+	start := 0
+	for i := range f.Stacks {
+		if i == fromStack {
+			f.Stacks[i] = slices.Insert(f.Stacks[i], flatIdx-start, item)
+			return
+		}
+		if fromStack == -1 && start == flatIdx {
+			f.Stacks = slices.Insert(f.Stacks, i, Stack{item})
+			return
+		}
+		start += len(f.Stacks[i])
+	}
+	f.Stacks = append(f.Stacks, Stack{item})
+}
+
+// stackCounts returns how many of each item the stack holds.
+func stackCounts(stack Stack) map[FarmItemType]int {
+	counts := map[FarmItemType]int{}
+	for _, item := range stack {
+		counts[item]++
+	}
+	return counts
+}
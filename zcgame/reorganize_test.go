@@ -0,0 +1,68 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMoveCard(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{Seed: 3, Reorganize: true}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	g := view.game
+	g.Players[0].Farm.Stacks = Stacks{{Shotgun}, {Ammo, Ammo}, {Scarecrow}}
+
+	if movable := g.Players[0].Farm.movableItems(); !slices.Equal(movable, []int{1, 2, 3}) {
+		t.Errorf("expected the Shotgun and both Ammo to be movable, got %v", movable)
+	}
+
+	_, input := view.ContinueDay()
+	_, input = view.ContinueAfterInput(0) // Skip the discard
+	if !slices.Contains(input.ValidChoices, PlayChoiceMove) {
+		t.Fatalf("expected a move on the play prompt, got %v", input.ValidChoices)
+	}
+	_, input = view.ContinueAfterInput(PlayChoiceMove)
+	if input.Context != InputContextMove {
+		t.Fatalf("expected a farm card choice, got context %d", input.Context)
+	}
+	_, input = view.ContinueAfterInput(1) // The Shotgun
+	if !slices.Contains(input.ValidChoices, StackChoiceCancel) {
+		t.Fatalf("expected a cancel on the stack prompt, got %v", input.ValidChoices)
+	}
+	_, input = view.ContinueAfterInput(StackChoiceCancel)
+	if input.Context != InputContextPlay || g.Players[0].Farm.Stacks.String() != (Stacks{{Shotgun}, {Ammo, Ammo}, {Scarecrow}}).String() {
+		t.Fatalf("expected the Shotgun back in place at the play prompt, got %s at context %d", g.Players[0].Farm.Stacks, input.Context)
+	}
+	_, input = view.ContinueAfterInput(PlayChoiceMove)
+	_, input = view.ContinueAfterInput(1)
+	if input.Context != InputContextPlayCard || !slices.Equal(input.ValidStacks, []int{0}) {
+		t.Fatalf("expected the Ammo pile as the only stack, got %v", input.ValidStacks)
+	}
+	_, input = view.ContinueAfterInput(1)
+
+	expected := Stacks{{Ammo, Ammo, Shotgun}, {Scarecrow}}
+	if g.Players[0].Farm.Stacks.String() != expected.String() {
+		t.Errorf("expected %s after the move, got %s", expected, g.Players[0].Farm.Stacks)
+	}
+	if input.Context != InputContextPlay || g.DaySubStage != DaySubStagePlay2 {
+		t.Errorf("expected the move to use the first play, got context %d at %d", input.Context, g.DaySubStage)
+	}
+
+	// Moves are only offered with the option on
+	view, err = CreateNewGameWithOptions(GameOptions{Seed: 3}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	view.game.Players[0].Farm.Stacks = Stacks{{Shotgun}, {Ammo, Ammo}, {Scarecrow}}
+	view.ContinueDay()
+	if _, input = view.ContinueAfterInput(0); slices.Contains(input.ValidChoices, PlayChoiceMove) {
+		t.Errorf("expected no move without GameOptions.Reorganize, got %v", input.ValidChoices)
+	}
+}
+
+func TestReorganizeGames(t *testing.T) {
+	for seed := range int64(20) {
+		simulateGame(t, GameOptions{Reorganize: true, Seed: seed + 1}, "a", "b", "c")
+	}
+}
@@ -0,0 +1,143 @@
+package zcgame
+
+// Farmer Roles
+//
+// With GameOptions.Roles, every player is dealt a role at the start of the game.
+// Each role has a passive or once-per-game power:
+//   - Rancher starts with a Hay Wall already built.
+//   - Gunsmith keeps their Ammo the first time they use it each night.
+//   - Mechanic keeps their Booby Trap the first time they use it (once per game).
+//   - Scavenger may take one face-up card and draw one from the deck.
+//
+// There are fewer roles than seats at large tables, so roles repeat once every
+// role has been dealt.
+
+import "slices"
+
+// dealRoles deals every player a role and applies the roles that change the
+// starting farm.
+func (g *gameState) dealRoles() {
+	roles := []Role{}
+	for len(roles) < len(g.Players) {
+		deal := []Role{}
+		for role := Rancher; role < NUM_ROLES; role++ {
+			deal = append(deal, role)
+		}
+		roles = append(roles, shuffle(g.rng, deal)...)
+	}
+
+	for i, player := range g.Players {
+		player.Role = roles[i]
+		if player.Role == Rancher {
+			g.buildStartingHayWall(player)
+		}
+	}
+}
+
+// buildStartingHayWall takes three Hay Bales out of the day deck and plays
+// them to the player's farm.
+func (g *gameState) buildStartingHayWall(player *Player) {
+	for range 3 {
+		if !g.takeFromDayDeck(HayBale) {
+			return
+		}
+		if result := player.Farm.PlayCard(HayBale, player.PlayChoices); result != nil {
+			// More than one incomplete wall can't happen on a new farm
+			player.Farm.addToStackIndex(HayBale, result.ValidStacks[0])
+		}
+	}
+}
+
+// takeFromDayDeck removes one copy of item from the day deck.
+// Returns false if the deck has none.
+func (g *gameState) takeFromDayDeck(item FarmItemType) bool {
+	for i, card := range g.DayDeck {
+		if card == item {
+			g.DayDeck = append(g.DayDeck[:i], g.DayDeck[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// farmOwner returns the player the farm belongs to, or nil.
+func (g *gameState) farmOwner(f *Farm) *Player {
+	for _, player := range g.Players {
+		if player.Farm == f {
+			return player
+		}
+	}
+	return nil
+}
+
+// keepsAmmo returns true if the Gunsmith's nightly power saves their Ammo,
+// and marks the power as used for tonight.
+func (g *gameState) keepsAmmo(player *Player) bool {
+	if player == nil || player.Role != Gunsmith || player.RoleUsedNight == g.NightNum {
+		return false
+	}
+	player.RoleUsedNight = g.NightNum
+	return true
+}
+
+// keepsBoobyTrap returns true if the Mechanic's once-per-game power saves
+// their Booby Trap, and marks the power as spent.
+func (g *gameState) keepsBoobyTrap(player *Player) bool {
+	if player == nil || player.Role != Mechanic || player.RoleUsed {
+		return false
+	}
+	player.RoleUsed = true
+	return true
+}
+
+// createDrawInput asks the current player how to draw their cards.
+func (g *gameState) createDrawInput() *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+	draws := g.Options.Rules.DrawsPerTurn
+	publicLeft := g.scavengedCard() != NUM_FARM_ITEMS
+
+	choices := []int{DrawChoiceDeck}
+	labels := map[int]Text{DrawChoiceDeck: countText(draws, MsgDrawDeckOne, MsgDrawDeck)}
+	if publicLeft {
+		choices = []int{DrawChoicePublic, DrawChoiceDeck}
+		public := g.PublicDayCards.String()
+		labels[DrawChoicePublic] = NewText(MsgDrawPublic, public)
+		if extra := draws - len(g.PublicDayCards); extra > 0 {
+			labels[DrawChoicePublic] = countText(extra, MsgDrawPublicMoreOne, MsgDrawPublicMore, public)
+		}
+		if player.Role == Scavenger && draws > 1 {
+			choices = append(choices, DrawChoiceMixed)
+			labels[DrawChoiceMixed] = countText(draws-1, MsgDrawScavengeOne, MsgDrawScavenge, g.scavengedCard())
+		}
+	}
+
+	return &PlayerInputNeeded{
+		Context:      InputContextDraw,
+		RenderType:   RenderNormal,
+		Text:         NewText(MsgDraw),
+		ValidChoices: choices,
+		LabelTexts:   labels,
+	}
+}
+
+// scavengedCard returns the face-up card a Scavenger takes with DrawChoiceMixed:
+// the first one that isn't an empty slot, or NUM_FARM_ITEMS if all are empty.
+func (g *gameState) scavengedCard() FarmItemType {
+	for _, card := range g.PublicDayCards {
+		if card != NUM_FARM_ITEMS {
+			return card
+		}
+	}
+	return NUM_FARM_ITEMS
+}
+
+// scavenge gives the current player one face-up card and the rest of their
+// draws from the deck, and refills the empty face-up slot.
+func (g *gameState) scavenge() {
+	player := g.CurrentPlayer()
+	if slot := slices.Index(g.PublicDayCards, g.scavengedCard()); player.Hand.Count() < len(player.Hand) {
+		player.Hand.add(g.PublicDayCards[slot])
+		g.PublicDayCards[slot] = g.nextDayCard()
+	}
+	g.drawDayCards(player, g.Options.Rules.DrawsPerTurn-1)
+}
@@ -0,0 +1,113 @@
+package zcgame
+
+import "testing"
+
+func TestRolesDealtAtStart(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f"}
+	view, err := CreateNewGameWithOptions(GameOptions{Roles: true}, names...)
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+
+	dealt := map[Role]int{}
+	for _, pv := range view.Players() {
+		dealt[pv.Role()]++
+		if pv.Role() == Rancher {
+			stacks := pv.Stacks()
+			if len(stacks) != 1 || countItemInStack(stacks[0], HayBale) != 3 {
+				t.Errorf("Rancher should start with a Hay Wall, got %s", stacks)
+			}
+			if pv.Lives() != StartingLivesLookup[len(names)] {
+				t.Errorf("Rancher should start with full lives, got %d", pv.Lives())
+			}
+		} else if len(pv.Stacks()) != 0 {
+			t.Errorf("%s should start with an empty farm, got %s", pv.Role(), pv.Stacks())
+		}
+	}
+	// Every role is dealt before any role repeats
+	for role := Rancher; role < NUM_ROLES; role++ {
+		if dealt[role] == 0 {
+			t.Errorf("%s was not dealt", role)
+		}
+	}
+}
+
+func TestGunsmithKeepsAmmoOncePerNight(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{}, "a", "b")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	g := view.game
+	player := g.Players[0]
+	player.Role = Gunsmith
+	player.Farm.Stacks = Stacks{{Shotgun, Ammo}}
+	zc := ZombieChicken{Name: "Test"}
+
+	player.Farm.UseDefenseStack(0, zc, false, g)
+	if !player.Farm.Stacks[0].HasItem(Ammo) {
+		t.Fatal("Gunsmith should keep their Ammo on the first use of the night")
+	}
+	player.Farm.UseDefenseStack(0, zc, false, g)
+	if len(player.Farm.Stacks) != 1 || player.Farm.Stacks[0].HasItem(Ammo) {
+		t.Fatalf("Gunsmith's Ammo should be used up on the second use of the night, got %s", player.Farm.Stacks)
+	}
+
+	g.NightNum++
+	player.Farm.Stacks[0] = append(player.Farm.Stacks[0], Ammo)
+	player.Farm.UseDefenseStack(0, zc, false, g)
+	if !player.Farm.Stacks[0].HasItem(Ammo) {
+		t.Fatal("Gunsmith's power should come back the next night")
+	}
+}
+
+func TestMechanicKeepsOneBoobyTrap(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{}, "a", "b")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	g := view.game
+	player := g.Players[0]
+	player.Role = Mechanic
+	player.Farm.Stacks = Stacks{{BoobyTrap}}
+	zc := ZombieChicken{Name: "Test"}
+
+	player.Farm.UseDefenseStack(0, zc, false, g)
+	if len(player.Farm.Stacks) != 1 || !player.RoleUsed {
+		t.Fatal("Mechanic should keep their first Booby Trap")
+	}
+	g.NightNum++
+	player.Farm.UseDefenseStack(0, zc, false, g)
+	if len(player.Farm.Stacks) != 0 {
+		t.Fatalf("Mechanic's power only works once per game, got %s", player.Farm.Stacks)
+	}
+}
+
+// TestRoleBalance plays seeded random games with roles and checks that no
+// role wins less than half or more than twice its fair share. The Rancher's
+// free Hay Wall wins about half again as often as the other roles.
+func TestRoleBalance(t *testing.T) {
+	games := 2000
+	if testing.Short() {
+		games = 200
+	}
+	names := []string{"a", "b", "c", "d"}
+	wins := map[Role]int{}
+	decided := 0
+
+	for i := range games {
+		_, winner := simulateGame(t, GameOptions{Roles: true, MaxNights: 10, Seed: int64(i + 1)}, names...)
+		if winner == nil {
+			continue
+		}
+		wins[winner.Role]++
+		decided++
+	}
+
+	fair := float64(decided) / float64(NUM_ROLES-1)
+	for role := Rancher; role < NUM_ROLES; role++ {
+		t.Logf("%-9s won %4d of %d games", role, wins[role], decided)
+		if share := float64(wins[role]) / fair; share < 0.5 || share > 2 {
+			t.Errorf("%s won %d games, expected around %.0f", role, wins[role], fair)
+		}
+	}
+}
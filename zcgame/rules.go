@@ -0,0 +1,164 @@
+package zcgame
+
+// House Rules
+//
+// The numbers printed on the rule sheet - five cards in hand, two plays and two
+// draws a turn, two face-up cards, one night card per night number and the
+// starting lives for the player count - live in GameOptions.Rules. The zero
+// value of every field keeps the printed rule, so existing callers play the
+// base game unchanged. CreateNewGameWithOptions fills in the defaults and
+// rejects rules that fail Validate; GameView.Options reports the filled in
+// values.
+//
+// Plays and draws per turn reuse the Play1/Play2 sub-stages: Play1 asks for
+// the first card and Play2 for every card after it, counted by PlaysMade.
+
+import "fmt"
+
+// Rules changes the numbers the game is played with. Zero fields keep the
+// printed rule (see DefaultRules).
+type Rules struct {
+	HandSize      int            // Cards in a full hand (0 for 5)
+	PlaysPerTurn  int            // Cards played (or moves made) each day turn (0 for 2)
+	DrawsPerTurn  int            // Cards drawn at the end of each day turn (0 for 2)
+	PublicCards   int            // Face-up cards available to draw; at most DrawsPerTurn (0 for 2)
+	StartingLives int            // Lives per player, or per teammate (0 uses StartingLivesLookup)
+	NightCards    NightCardCurve // How many night cards each player is dealt as the nights go on
+}
+
+// DefaultRules are the printed rules. StartingLives is left at 0 because it
+// depends on the player count.
+var DefaultRules = Rules{
+	HandSize:     5,
+	PlaysPerTurn: 2,
+	DrawsPerTurn: 2,
+	PublicCards:  2,
+}
+
+// MaxHandSize is the largest hand Rules.HandSize allows.
+const MaxHandSize = 10
+
+// NightCardCurve decides how many night cards each player is dealt on a night.
+type NightCardCurve uint8
+
+const (
+	NightCardsRising NightCardCurve = iota // One card per night number: 1, 2, 3, ...
+	NightCardsHalf                         // Half the night number plus one: 1, 2, 2, 3, 3, ...
+	NightCardsOne                          // One card every night
+)
+
+// ParseNightCardCurve parses the String form of a NightCardCurve.
+func ParseNightCardCurve(s string) (NightCardCurve, error) {
+	for curve := NightCardsRising; curve <= NightCardsOne; curve++ {
+		if curve.String() == s {
+			return curve, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown night card curve %q (want rising, half or one)", s)
+}
+
+// count returns the night cards each player is dealt on the given night.
+func (c NightCardCurve) count(nightNum int) int {
+	switch c {
+	case NightCardsHalf:
+		return nightNum/2 + 1
+	case NightCardsOne:
+		return 1
+	default:
+		return nightNum
+	}
+}
+
+// WithDefaults returns the rules with every zero field set to its printed value.
+func (r Rules) WithDefaults() Rules {
+	if r.HandSize == 0 {
+		r.HandSize = DefaultRules.HandSize
+	}
+	if r.PlaysPerTurn == 0 {
+		r.PlaysPerTurn = DefaultRules.PlaysPerTurn
+	}
+	if r.DrawsPerTurn == 0 {
+		r.DrawsPerTurn = DefaultRules.DrawsPerTurn
+	}
+	if r.PublicCards == 0 {
+		r.PublicCards = min(DefaultRules.PublicCards, r.DrawsPerTurn)
+	}
+	return r
+}
+
+// Validate returns an error describing the first rule the game can't be
+// played with. Zero fields are checked as their defaults.
+func (r Rules) Validate() error {
+	r = r.WithDefaults()
+	switch {
+	case r.HandSize < 1 || r.HandSize > MaxHandSize:
+		return fmt.Errorf("hand size must be between 1 and %d, got %d", MaxHandSize, r.HandSize)
+	case r.PlaysPerTurn < 1 || r.PlaysPerTurn > r.HandSize:
+		return fmt.Errorf("plays per turn must be between 1 and the hand size (%d), got %d", r.HandSize, r.PlaysPerTurn)
+	case r.DrawsPerTurn < 1 || r.DrawsPerTurn > r.HandSize:
+		return fmt.Errorf("draws per turn must be between 1 and the hand size (%d), got %d", r.HandSize, r.DrawsPerTurn)
+	case r.PublicCards < 1 || r.PublicCards > r.DrawsPerTurn:
+		return fmt.Errorf("face-up cards must be between 1 and the draws per turn (%d), got %d", r.DrawsPerTurn, r.PublicCards)
+	case r.StartingLives < 0:
+		return fmt.Errorf("starting lives can't be negative, got %d", r.StartingLives)
+	case r.NightCards > NightCardsOne:
+		return fmt.Errorf("invalid night card curve %d", r.NightCards)
+	}
+	return nil
+}
+
+// finishPlay counts a play made this turn and moves on to the next play, or
+// to drawing once every play has been made.
+func (g *gameState) finishPlay() {
+	g.PlaysMade++
+	if g.PlaysMade < g.Options.Rules.PlaysPerTurn {
+		g.DaySubStage = DaySubStagePlay2
+	} else {
+		g.DaySubStage = DaySubStageDraw
+	}
+}
+
+// playText returns the prompt for the play the current player is making.
+func (g *gameState) playText() Text {
+	switch g.PlaysMade {
+	case 0:
+		return NewText(MsgPlayFirst)
+	case 1:
+		return NewText(MsgPlaySecond)
+	default:
+		return NewText(MsgPlayNth, g.PlaysMade+1, g.Options.Rules.PlaysPerTurn)
+	}
+}
+
+// drawDayCards draws up to n cards from the day deck into the player's hand,
+// stopping once the hand is full so no card is drawn only to be lost.
+func (g *gameState) drawDayCards(player *Player, n int) {
+	for range n {
+		if player.Hand.Count() >= len(player.Hand) {
+			return
+		}
+		player.Hand.add(g.nextDayCard())
+	}
+}
+
+// takePublicDayCards moves the face-up cards into the player's hand while it
+// has room, then refills the empty face-up slots.
+func (g *gameState) takePublicDayCards(player *Player) {
+	for i, card := range g.PublicDayCards {
+		if card != NUM_FARM_ITEMS && player.Hand.Count() < len(player.Hand) {
+			player.Hand.add(card)
+			g.PublicDayCards[i] = NUM_FARM_ITEMS
+		}
+	}
+	g.refillPublicDayCards()
+}
+
+// refillPublicDayCards deals a card from the day deck into every empty
+// face-up slot.
+func (g *gameState) refillPublicDayCards() {
+	for i, card := range g.PublicDayCards {
+		if card == NUM_FARM_ITEMS {
+			g.PublicDayCards[i] = g.nextDayCard()
+		}
+	}
+}
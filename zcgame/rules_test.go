@@ -0,0 +1,45 @@
+package zcgame
+
+import "testing"
+
+func TestRules(t *testing.T) {
+	rules := Rules{HandSize: 7, PlaysPerTurn: 3, DrawsPerTurn: 3, PublicCards: 1, StartingLives: 2, NightCards: NightCardsHalf}
+	view, err := CreateNewGameWithOptions(GameOptions{Rules: rules, Seed: 5}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	player := view.Player(0)
+	if player.Hand().Count() != 7 || player.Lives() != 2 || len(view.PublicDayCards()) != 1 {
+		t.Fatalf("expected 7 cards, 2 lives and 1 face-up card, got %d, %d and %d", player.Hand().Count(), player.Lives(), len(view.PublicDayCards()))
+	}
+
+	plays := 0
+	_, input := view.ContinueDay()
+	for input != nil && input.Context != InputContextDraw {
+		if input.Context == InputContextPlay {
+			plays++
+		}
+		_, input = view.ContinueAfterInput(input.ValidChoices[0])
+	}
+	if plays != 3 {
+		t.Errorf("expected 3 plays, got %d", plays)
+	}
+	if input == nil {
+		t.Fatal("expected a draw prompt")
+	}
+	view.ContinueAfterInput(DrawChoiceDeck)
+	if player.Hand().Count() != 7 {
+		t.Errorf("expected a full hand of 7 after drawing, got %s", player.Hand())
+	}
+
+	for night, want := range []int{1, 2, 2, 3, 3} {
+		if got := NightCardsHalf.count(night + 1); got != want {
+			t.Errorf("night %d: expected %d night cards, got %d", night+1, want, got)
+		}
+	}
+	if _, err := CreateNewGameWithOptions(GameOptions{Rules: Rules{PublicCards: 3}}, "p1"); err == nil {
+		t.Error("expected more face-up cards than draws to be rejected")
+	}
+	simulateGame(t, GameOptions{Rules: rules}, "p1", "p2", "p3")
+	simulateGame(t, GameOptions{Rules: Rules{HandSize: 3, PlaysPerTurn: 1, DrawsPerTurn: 1}, Mode: ModeCoop}, "p1", "p2")
+}
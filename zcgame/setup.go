@@ -0,0 +1,145 @@
+package zcgame
+
+// Setup Variants
+//
+// GameOptions.Setup chooses how opening hands are dealt. Both variants play out
+// in the Setup turn before the first Morning:
+//   - Mulligan: every player is dealt a hand as usual, then in turn order may
+//     shuffle their hand back into the deck and draw a new one, once.
+//   - Draft: Rules.HandSize cards per player are laid out face up, and players
+//     take one at a time in snake order (1, 2, 3, 3, 2, 1, 1, ...) until every
+//     hand is full.
+
+import "fmt"
+
+// SetupVariant decides how opening hands are dealt.
+type SetupVariant uint8
+
+const (
+	SetupDeal     SetupVariant = iota // Every player is dealt a hand from the deck
+	SetupMulligan                     // Dealt as usual; each player may redraw their hand once
+	SetupDraft                        // Players pick their hands from a face-up pool in snake order
+)
+
+// ParseSetupVariant parses the String form of a SetupVariant.
+func ParseSetupVariant(s string) (SetupVariant, error) {
+	for setup := SetupDeal; setup <= SetupDraft; setup++ {
+		if setup.String() == s {
+			return setup, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown setup %q (want deal, mulligan or draft)", s)
+}
+
+// Choices for InputContextMulligan.
+const (
+	MulliganChoiceKeep   = 0 // Keep the opening hand
+	MulliganChoiceRedraw = 1 // Shuffle the hand into the deck and draw 5 new cards
+)
+
+// startSetup puts a new game into the Setup turn if its setup variant needs one.
+// For a draft, the dealt hands become the face-up pool.
+func (g *gameState) startSetup() {
+	if g.Options.Setup == SetupDeal {
+		return
+	}
+	g.Turn = Setup
+	g.StageInTurn = ChooseHand
+	if g.Options.Setup == SetupDraft {
+		g.DraftPool = Stack{}
+		for _, player := range g.Players {
+			for i, item := range player.Hand {
+				if item.FarmItemType != NUM_FARM_ITEMS {
+					g.DraftPool = append(g.DraftPool, item.FarmItemType)
+				}
+				player.Hand[i] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+			}
+		}
+		g.DraftPool.Sort()
+	}
+}
+
+// doSetupTurn processes the Setup turn using the same state machine pattern as
+// doPlayerDayTurn. Returns nil once every opening hand is settled, or
+// *PlayerInputNeeded when input is required.
+func (g *gameState) doSetupTurn() *PlayerInputNeeded {
+	switch g.Options.Setup {
+	case SetupMulligan:
+		if g.PlayerTurnIndex < len(g.Players) {
+			g.CurrentPlayerIdx = g.PlayerTurnIndex
+			g.CurrentPlayer().Hand.Sort()
+			return &PlayerInputNeeded{
+				Context:      InputContextMulligan,
+				RenderType:   RenderNormal,
+				Text:         NewText(MsgMulligan, g.Options.Rules.HandSize),
+				ValidChoices: []int{MulliganChoiceRedraw, MulliganChoiceKeep},
+				LabelTexts: map[int]Text{
+					MulliganChoiceRedraw: NewText(MsgMulliganRedraw),
+					MulliganChoiceKeep:   NewText(MsgMulliganKeep),
+				},
+			}
+		}
+	case SetupDraft:
+		if len(g.DraftPool) > 0 && g.DraftPick < len(g.Players)*g.Options.Rules.HandSize {
+			g.CurrentPlayerIdx = g.draftPicker()
+			choices := []int{}
+			labels := map[int]Text{}
+			for i, item := range g.DraftPool {
+				choices = append(choices, i+1)
+				labels[i+1] = plainText(item.String())
+			}
+			return &PlayerInputNeeded{
+				Context:      InputContextDraft,
+				RenderType:   RenderNormal,
+				Text:         NewText(MsgDraft, g.DraftPick/len(g.Players)+1, g.Options.Rules.HandSize),
+				ValidChoices: choices,
+				LabelTexts:   labels,
+			}
+		}
+	}
+
+	// Every hand is settled - the first player starts the Morning
+	g.CurrentPlayerIdx = 0
+	g.PlayerTurnIndex = 0
+	g.DraftPool = nil
+	return nil
+}
+
+// draftPicker returns the index of the player making the current draft pick.
+// Picks go round the table and back again.
+func (g *gameState) draftPicker() int {
+	round, seat := g.DraftPick/len(g.Players), g.DraftPick%len(g.Players)
+	if round%2 == 1 {
+		return len(g.Players) - 1 - seat
+	}
+	return seat
+}
+
+// provideSetupInput handles input during the Setup turn.
+func (g *gameState) provideSetupInput(choice int) *PlayerInputNeeded {
+	player := g.CurrentPlayer()
+
+	switch g.Options.Setup {
+	case SetupMulligan:
+		if choice == MulliganChoiceRedraw {
+			for i, item := range player.Hand {
+				if item.FarmItemType != NUM_FARM_ITEMS {
+					g.DayDeck = append(g.DayDeck, item.FarmItemType)
+				}
+				player.Hand[i] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+			}
+			shuffle(g.rng, g.DayDeck)
+			for i := range player.Hand {
+				player.Hand[i] = HandItem{FarmItemType: g.nextDayCard()}
+			}
+			player.Hand.Sort()
+		}
+		g.PlayerTurnIndex++
+	case SetupDraft:
+		player.Hand.add(g.DraftPool[choice-1])
+		player.Hand.Sort()
+		g.DraftPool = append(g.DraftPool[:choice-1], g.DraftPool[choice:]...)
+		g.DraftPick++
+	}
+	return nil
+}
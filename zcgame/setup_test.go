@@ -0,0 +1,42 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDraftSetup(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{Setup: SetupDraft, Seed: 7}, "p1", "p2", "p3")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	if len(view.DraftPool()) != 15 {
+		t.Fatalf("expected a pool of 15 cards, got %d", len(view.DraftPool()))
+	}
+
+	pickers := []int{}
+	_, input := view.ContinueDay()
+	for input != nil && input.Context == InputContextDraft {
+		pickers = append(pickers, view.ActiveInputPlayerIdx())
+		_, input = view.ContinueAfterInput(input.ValidChoices[0])
+	}
+	expected := []int{0, 1, 2, 2, 1, 0, 0, 1, 2, 2, 1, 0, 0, 1, 2}
+	if !slices.Equal(pickers, expected) {
+		t.Errorf("expected picks in snake order %v, got %v", expected, pickers)
+	}
+	if view.Turn() != Morning || len(view.DraftPool()) != 0 {
+		t.Errorf("expected the Morning with an empty pool, got %s with %d cards", view.Turn(), len(view.DraftPool()))
+	}
+	for i, player := range view.Players() {
+		for _, item := range player.Hand() {
+			if item.FarmItemType == NUM_FARM_ITEMS {
+				t.Errorf("Players[%d] has an empty slot after the draft: %s", i, player.Hand())
+				break
+			}
+		}
+	}
+
+	for _, setup := range []SetupVariant{SetupMulligan, SetupDraft} {
+		simulateGame(t, GameOptions{Setup: setup}, "p1", "p2")
+	}
+}
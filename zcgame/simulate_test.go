@@ -0,0 +1,81 @@
+package zcgame
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// simulateGame plays a game to the end, picking a random valid choice at
+// every prompt. The choices are seeded from the game's seed, so a game with
+// GameOptions.Seed set plays out the same way every time. Returns the finished game state and the winner: the player
+// with the most lives at the end, or the last player left standing before
+// everyone was eliminated. The winner is nil if there wasn't one.
+func simulateGame(t *testing.T, options GameOptions, playerNames ...string) (*gameState, *Player) {
+	t.Helper()
+	view, err := CreateNewGameWithOptions(options, playerNames...)
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	return playGame(t, view.game)
+}
+
+// playGame plays g to the end like simulateGame.
+func playGame(t *testing.T, g *gameState) (*gameState, *Player) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(g.Seed))
+	var lastStanding *Player
+	watch := func() {
+		if len(g.Players) == 1 {
+			lastStanding = g.Players[0]
+		}
+	}
+
+	for steps := 0; steps < 100000; steps++ {
+		cont, input := g.ContinueDay()
+		for input != nil {
+			if len(input.ValidChoices) == 0 {
+				t.Fatalf("prompt %q has no valid choices", input.Message)
+			}
+			if !slices.Contains(input.ValidChoices, input.DefaultChoice) {
+				t.Fatalf("prompt %q defaults to %d, not one of %v", input.Message, input.DefaultChoice, input.ValidChoices)
+			}
+			for _, lang := range Languages {
+				if text := localizedText(input.Localized(lang)); strings.Contains(text, "%!") {
+					t.Fatalf("prompt %q doesn't format in %s: %s", input.Message, lang, text)
+				}
+			}
+			cont, input = g.ContinueAfterInput(randomChoice(rng, input))
+			watch()
+		}
+		watch()
+		for i, player := range g.Players {
+			if err := player.Farm.assertLegalStacks(); err != nil {
+				t.Fatalf("Players[%d] has an illegal farm: %v", i, err)
+			}
+		}
+		if !cont {
+			if g.EndReason == GameEndMaxNights {
+				return g, g.Standings()[0]
+			}
+			return g, lastStanding
+		}
+	}
+	t.Fatal("game did not end")
+	return nil, nil
+}
+
+// localizedText joins the prompt's message, labels and tip.
+func localizedText(input *PlayerInputNeeded) string {
+	text := input.Message + "\n" + input.Tip
+	for _, label := range input.ChoiceLabels {
+		text += "\n" + label
+	}
+	return text
+}
+
+// randomChoice picks a random valid choice.
+func randomChoice(rng *rand.Rand, input *PlayerInputNeeded) int {
+	return input.ValidChoices[rng.Intn(len(input.ValidChoices))]
+}
@@ -0,0 +1,78 @@
+package zcgame
+
+// Simultaneous Discards
+//
+// Lightning Storm and Tornado normally ask each player for their discards in
+// turn, starting with the player who drew the event. The choices don't depend
+// on each other, so with GameOptions.SimultaneousDiscards every player who has
+// to choose is asked at once. EventDiscardsLeft counts the cards each player
+// still owes, PendingInputs lists a prompt for each of them, and
+// ContinueAfterInputFrom takes an answer from any of them in any order. The
+// event resolves once the last player has discarded.
+//
+// Simple clients can ignore all of this: the prompt handed back by ContinueDay
+// and ContinueAfterInput is always one of the pending ones, so answering them
+// in turn works just like sequential discards.
+
+// startSimultaneousDiscard works out what every player owes for the event.
+// Players with no more cards than the event takes lose them all without being
+// asked.
+func (g *gameState) startSimultaneousDiscard() {
+	g.EventDiscardsLeft = make([]int, len(g.Players))
+	for i, player := range g.Players {
+		if g.EventDiscardTotal < player.Farm.Stacks.TotalItems() {
+			g.EventDiscardsLeft[i] = g.EventDiscardTotal
+			continue
+		}
+		for _, stack := range player.Farm.Stacks {
+			for _, item := range stack {
+				g.discardDayCard(item)
+			}
+		}
+		player.Farm.Stacks = Stacks{}
+	}
+}
+
+// nextSimultaneousDiscard returns the prompt of the first player, counting from
+// the one who drew the event, who still has cards to discard. Returns nil once
+// everybody has answered.
+func (g *gameState) nextSimultaneousDiscard() *PlayerInputNeeded {
+	for offset := range g.Players {
+		idx := (g.EventDiscardStartIdx + offset) % len(g.Players)
+		if g.EventDiscardsLeft[idx] > 0 {
+			g.EventDiscardPlayerIdx = offset
+			g.EventDiscardRemaining = g.EventDiscardsLeft[idx]
+			return g.eventDiscardInput(idx, g.EventDiscardsLeft[idx])
+		}
+	}
+	return nil
+}
+
+// PendingInputs returns the prompts waiting to be answered, keyed by the index
+// of the player who answers them. Usually that is just ActiveInputPlayerIdx,
+// but during simultaneous discards every player still owing cards is waiting.
+// Returns nil when no input is needed.
+func (g *gameState) PendingInputs() map[int]*PlayerInputNeeded {
+	if g.lastInput == nil || g.EndReason != GameNotOver {
+		return nil
+	}
+	active := g.activeInputPlayerIdx()
+	inputs := map[int]*PlayerInputNeeded{active: g.lastInput}
+	for idx, left := range g.EventDiscardsLeft {
+		if left > 0 && idx != active {
+			inputs[idx] = inEnglish(g.attachDefault(g.eventDiscardInput(idx, left)))
+		}
+	}
+	return inputs
+}
+
+// ContinueAfterInputFrom answers the prompt waiting on the given player (see
+// PendingInputs). Outside of simultaneous discards only ActiveInputPlayerIdx
+// is waiting, and this is the same as ContinueAfterInput. As there, the choice
+// must already be validated against that player's prompt.
+func (g *gameState) ContinueAfterInputFrom(playerIdx int, choice int) (bool, *PlayerInputNeeded) {
+	if playerIdx >= 0 && playerIdx < len(g.EventDiscardsLeft) {
+		g.EventDiscardPlayerIdx = (playerIdx - g.EventDiscardStartIdx + len(g.Players)) % len(g.Players)
+	}
+	return g.ContinueAfterInput(choice)
+}
@@ -0,0 +1,37 @@
+package zcgame
+
+import "testing"
+
+func TestSimultaneousDiscards(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{SimultaneousDiscards: true}, "p1", "p2", "p3")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	g := view.game
+	g.Turn = Night
+	g.Players[0].Farm.Stacks = Stacks{{Shotgun, Ammo}, {Scarecrow}}
+	g.Players[1].Farm.Stacks = Stacks{{HayBale}}
+	g.Players[2].Farm.Stacks = Stacks{{Shotgun, Ammo}, {Scarecrow}}
+
+	g.lastInput = g.startEventDiscard(2)
+	pending := view.PendingInputs()
+	if len(pending) != 2 || pending[0] == nil || pending[2] == nil {
+		t.Fatalf("expected p1 and p3 to be asked at once, got %v", pending)
+	}
+	if len(g.Players[1].Farm.Stacks) != 0 {
+		t.Errorf("expected p2 to lose their only card without being asked, got %s", g.Players[1].Farm.Stacks)
+	}
+
+	// p3 answers before p1
+	_, input := view.ContinueAfterInputsFrom(2, []int{1, 3})
+	if g.Players[2].Farm.Stacks.String() != (Stacks{{Ammo}}).String() {
+		t.Errorf("expected p3 to keep their Ammo, got %s", g.Players[2].Farm.Stacks)
+	}
+	if pending := view.PendingInputs(); len(pending) != 1 || view.ActiveInputPlayerIdx() != 0 || input.SelectCount != 2 {
+		t.Fatalf("expected only p1 to be waiting, got %v", pending)
+	}
+	_, input = view.ContinueAfterInputFrom(0, 3)
+	if view.ActiveInputPlayerIdx() != 0 || input.SelectCount != 1 {
+		t.Errorf("expected p1 to owe one more card, got player %d owing %d", view.ActiveInputPlayerIdx(), input.SelectCount)
+	}
+}
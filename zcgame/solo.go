@@ -0,0 +1,83 @@
+package zcgame
+
+// Solo Challenge
+//
+// In solo games (GameOptions.Mode == ModeSolo) one player plays until their farm
+// falls, or until MaxNights. The game is scored on:
+//   - nights survived
+//   - zombies killed
+//   - cards left on the farm and in hand when the game ends
+//
+// The daily challenge fixes the options and derives the seed from the date, so
+// everyone playing on the same day gets the same decks.
+
+import "time"
+
+// Points per scored item in a solo game.
+const (
+	ScorePerNight = 100 // Each night survived
+	ScorePerKill  = 25  // Each zombie killed (bosses count once)
+	ScorePerCard  = 5   // Each card left on the farm or in hand
+)
+
+// SoloScore is the breakdown of a solo game's score.
+type SoloScore struct {
+	Nights    int `json:"nights"`     // Nights survived
+	Kills     int `json:"kills"`      // Zombies killed
+	CardsLeft int `json:"cards_left"` // Cards on the farm and in hand at the end
+	Total     int `json:"total"`      // Weighted sum of the above
+}
+
+// isSolo returns true if the game is a scored solo game.
+func (g *gameState) isSolo() bool {
+	return g.Options.Mode == ModeSolo
+}
+
+// countCardsLeft records how many cards the solo player has on their farm and
+// in their hand. It is called when the player is eliminated (before their cards
+// are discarded) and when the game ends with the player still standing.
+func (g *gameState) countCardsLeft(player *Player) {
+	if !g.isSolo() {
+		return
+	}
+	g.CardsLeft = player.Farm.Stacks.TotalItems() + player.Hand.Count()
+}
+
+// SoloScore returns the score of a solo game so far.
+func (g *gameState) SoloScore() SoloScore {
+	score := SoloScore{
+		Nights:    g.NightsSurvived,
+		Kills:     g.ZombiesKilled,
+		CardsLeft: g.CardsLeft,
+	}
+	if g.EndReason == GameNotOver && len(g.Players) == 1 {
+		score.CardsLeft = g.Players[0].Farm.Stacks.TotalItems() + g.Players[0].Hand.Count()
+	}
+	score.Total = score.Nights*ScorePerNight + score.Kills*ScorePerKill + score.CardsLeft*ScorePerCard
+	return score
+}
+
+// DailySeed returns the seed of the daily challenge on the given day.
+// The date is read in UTC so everyone shares the same daily challenge.
+func DailySeed(day time.Time) int64 {
+	y, m, d := day.UTC().Date()
+	return int64(y*10000 + int(m)*100 + d)
+}
+
+// DailyDate returns the date of the daily challenge on the given day, as stored
+// in LeaderboardEntry.Daily.
+func DailyDate(day time.Time) string {
+	return day.UTC().Format(time.DateOnly)
+}
+
+// DailyChallenge returns the options of the daily challenge on the given day.
+// Every player gets the same rules and the same seed.
+func DailyChallenge(day time.Time) GameOptions {
+	return GameOptions{
+		Mode:             ModeSolo,
+		DefenseExpansion: true,
+		ZombieExpansion:  true,
+		BossEvery:        4,
+		Seed:             DailySeed(day),
+	}
+}
@@ -0,0 +1,49 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSeedDealsSameCards(t *testing.T) {
+	options := DailyChallenge(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	a, err := CreateNewGameWithOptions(options, "a")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	b, err := CreateNewGameWithOptions(options, "b")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+
+	if !slices.Equal(a.game.DayDeck, b.game.DayDeck) || !slices.Equal(a.Player(0).Hand(), b.Player(0).Hand()) {
+		t.Error("games with the same seed should deal the same day cards")
+	}
+	// Events hold funcs, so compare night cards by zombie and event name
+	for i, card := range a.game.NightDeck {
+		other := b.game.NightDeck[i]
+		if card.ZombieKey != other.ZombieKey || card.Event.Name != other.Event.Name {
+			t.Fatalf("games with the same seed should deal the same night cards, NightDeck[%d] differs", i)
+		}
+	}
+	if a.Seed() != 20261018 {
+		t.Errorf("expected the daily seed 20261018, got %d", a.Seed())
+	}
+}
+
+func TestSoloScore(t *testing.T) {
+	for range 50 {
+		g, _ := simulateGame(t, GameOptions{Mode: ModeSolo, MaxNights: 5}, "solo")
+		score := g.SoloScore()
+		if score.Nights > 5 || (g.EndReason == GameEndMaxNights) != (score.Nights == 5) {
+			t.Fatalf("%s after %d nights survived", g.EndReason, score.Nights)
+		}
+		if g.EndReason == GameEndAllEliminated && score.CardsLeft == 0 {
+			t.Error("cards left should be counted before an eliminated player's cards are discarded")
+		}
+		if score.Total != score.Nights*ScorePerNight+score.Kills*ScorePerKill+score.CardsLeft*ScorePerCard {
+			t.Errorf("total %d doesn't add up for %+v", score.Total, score)
+		}
+	}
+}
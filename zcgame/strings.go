@@ -31,6 +31,22 @@ func (f FarmItemType) String() string {
 		return "Fuel"
 	case WOLR:
 		return "W.O.L.R*"
+	case Pitchfork:
+		return "Pitchfork"
+	case GuardDog:
+		return "Guard Dog"
+	case ElectricFence:
+		return "Electric Fence"
+	case Generator:
+		return "Generator"
+	case WornPitchfork:
+		return "Worn Pitchfork*"
+	case Bait:
+		return "Bait*"
+	case Sabotage:
+		return "Sabotage!"
+	case Trade:
+		return "Trade!"
 	default:
 		return fmt.Sprintf("FarmItemType ERROR %d", int(f))
 	}
@@ -46,11 +62,143 @@ func (t Turn) String() string {
 		return "Night"
 	case Day:
 		return "Day"
+	case Setup:
+		return "Setup"
 	default:
 		return fmt.Sprintf("Turn ERROR %d", int(t))
 	}
 }
 
+func (c ShieldChoice) String() string {
+	switch c {
+	case ShieldAsk:
+		return "ask"
+	case ShieldAlways:
+		return "always"
+	case ShieldNever:
+		return "never"
+	default:
+		return fmt.Sprintf("ShieldChoice ERROR %d", int(c))
+	}
+}
+
+func (s SetupVariant) String() string {
+	switch s {
+	case SetupDeal:
+		return "deal"
+	case SetupMulligan:
+		return "mulligan"
+	case SetupDraft:
+		return "draft"
+	default:
+		return fmt.Sprintf("SetupVariant ERROR %d", int(s))
+	}
+}
+
+func (r DeckExhaustionRule) String() string {
+	switch r {
+	case DeckExhaustionReshuffle:
+		return "reshuffle"
+	case DeckExhaustionSkip:
+		return "skip"
+	case DeckExhaustionEndGame:
+		return "end"
+	default:
+		return fmt.Sprintf("DeckExhaustionRule ERROR %d", int(r))
+	}
+}
+
+func (c NightCardCurve) String() string {
+	switch c {
+	case NightCardsRising:
+		return "rising"
+	case NightCardsHalf:
+		return "half"
+	case NightCardsOne:
+		return "one"
+	default:
+		return fmt.Sprintf("NightCardCurve ERROR %d", int(c))
+	}
+}
+
+func (m GameMode) String() string {
+	switch m {
+	case ModeCompetitive:
+		return "competitive"
+	case ModeCoop:
+		return "co-op"
+	case ModeTeams:
+		return "teams"
+	case ModeSolo:
+		return "solo"
+	default:
+		return fmt.Sprintf("GameMode ERROR %d", int(m))
+	}
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleNone:
+		return "None"
+	case Rancher:
+		return "Rancher"
+	case Gunsmith:
+		return "Gunsmith"
+	case Mechanic:
+		return "Mechanic"
+	case Scavenger:
+		return "Scavenger"
+	default:
+		return fmt.Sprintf("Role ERROR %d", int(r))
+	}
+}
+
+// Description returns a short summary of the role's ability.
+func (r Role) Description() string {
+	switch r {
+	case Rancher:
+		return "Starts with a Hay Wall"
+	case Gunsmith:
+		return "Ammo isn't used up the first time each night"
+	case Mechanic:
+		return "Keeps the first Booby Trap used (once per game)"
+	case Scavenger:
+		return "May take one face-up card and draw one from the deck"
+	default:
+		return ""
+	}
+}
+
+// Explanation returns the tutorial text for the tip in English.
+func (t Tip) Explanation() string {
+	return t.Text().String()
+}
+
+func (r GameEndReason) String() string {
+	switch r {
+	case GameNotOver:
+		return "The game is still going"
+	case GameEndAllEliminated:
+		return "All players have been eliminated"
+	case GameEndDeckExhausted:
+		return "The decks ran out"
+	case GameEndMaxNights:
+		return "The last night is over"
+	case GameEndTeamSurvived:
+		return "The team survived"
+	case GameEndTeamDefeated:
+		return "The team ran out of lives"
+	case GameEndLastTeamStanding:
+		return "One team is left standing"
+	case GameEndLevelComplete:
+		return "Level complete"
+	case GameEndLevelFailed:
+		return "Level failed"
+	default:
+		return fmt.Sprintf("GameEndReason ERROR %d", int(r))
+	}
+}
+
 func (zt ZombieTrait) String() string {
 	switch zt {
 	case Invisible:
@@ -67,6 +215,14 @@ func (zt ZombieTrait) String() string {
 		return "Timid"
 	case Exploding:
 		return "Exploding"
+	case Armored:
+		return "Armored"
+	case Swarm:
+		return "Swarm"
+	case Burrowing:
+		return "Burrowing"
+	case Contagious:
+		return "Contagious"
 	default:
 		return fmt.Sprintf("ZombieTrait ERROR %d", int(zt))
 	}
@@ -87,6 +243,10 @@ func (s StageInTurn) String() string {
 		return "Draw 2 cards from the deck or the 2 face-up cards"
 	case Nighttime:
 		return "Progress through the night..."
+	case ShareCards:
+		return "Give a card to a teammate's farm (optional)"
+	case ChooseHand:
+		return "Choose your opening hand"
 	default:
 		return fmt.Sprintf("StageInTurn ERROR %d", int(s))
 	}
@@ -247,15 +407,15 @@ func (h HandItem) String() string {
 	return fmt.Sprintf("%s", h.FarmItemType)
 }
 
-func (h *Hand) String() string {
+func (h Hand) String() string {
 	return h.stringWithIndices(true)
 }
 
-func (h *Hand) StringWithoutIndices() string {
+func (h Hand) StringWithoutIndices() string {
 	return h.stringWithIndices(false)
 }
 
-func (h *Hand) stringWithIndices(showIndices bool) string {
+func (h Hand) stringWithIndices(showIndices bool) string {
 	result := "Hand: { "
 	first := true
 	idx := 1
@@ -0,0 +1,192 @@
+package zcgame
+
+// Team Mode
+//
+// In team games (GameOptions.Mode == ModeTeams) 4 to MaxPlayers seats are split
+// into teams of at least two:
+//   - Teammates put their lives into one shared pool, which each Player.Lives mirrors.
+//   - When the pool runs dry the whole team is eliminated together.
+//   - Teammates may look at each other's hands; opponents' hands stay hidden.
+//   - The last team standing wins, or the team with the most lives after MaxNights.
+//
+// Every game tracks a Player.Team so lives are always lost through the same
+// code: in competitive games every player is their own team, and in co-op the
+// whole table is one team.
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// MinTeamPlayers is the fewest players a team game supports.
+const MinTeamPlayers = 4
+
+// isTeams returns true if the game is played in team mode.
+func (g *gameState) isTeams() bool {
+	return g.Options.Mode == ModeTeams
+}
+
+// seatTeams returns the team of each seat for the given options.
+func seatTeams(options GameOptions, numPlayers int) []int {
+	teams := make([]int, numPlayers)
+	for i := range teams {
+		switch {
+		case options.Mode == ModeCoop:
+			teams[i] = 0
+		case options.Mode != ModeTeams:
+			teams[i] = i
+		case options.Teams != nil:
+			teams[i] = options.Teams[i]
+		default:
+			teams[i] = i % 2
+		}
+	}
+	return teams
+}
+
+// countTeam returns how many seats belong to the team.
+func countTeam(teams []int, team int) int {
+	count := 0
+	for _, t := range teams {
+		if t == team {
+			count++
+		}
+	}
+	return count
+}
+
+// validateTeams checks the team options against the number of players.
+func validateTeams(options GameOptions, numPlayers int) error {
+	if options.Mode != ModeTeams {
+		if options.Teams != nil {
+			return fmt.Errorf("teams can only be chosen in team mode")
+		}
+		return nil
+	}
+	if numPlayers < MinTeamPlayers {
+		return fmt.Errorf("team mode needs at least %d players, got %d", MinTeamPlayers, numPlayers)
+	}
+	if options.Teams != nil && len(options.Teams) != numPlayers {
+		return fmt.Errorf("teams must list a team for each of the %d players, got %d", numPlayers, len(options.Teams))
+	}
+
+	teams := seatTeams(options, numPlayers)
+	sizes := map[int]int{}
+	for _, team := range teams {
+		if team < 0 {
+			return fmt.Errorf("team numbers cannot be negative, got %d", team)
+		}
+		sizes[team]++
+	}
+	if len(sizes) < 2 {
+		return fmt.Errorf("team mode needs at least 2 teams")
+	}
+	for team, size := range sizes {
+		if size < 2 {
+			return fmt.Errorf("team %d needs at least 2 players, got %d", team+1, size)
+		}
+	}
+	return nil
+}
+
+// teamOf returns the remaining players on the player's team, including the player.
+func (g *gameState) teamOf(player *Player) Players {
+	team := Players{}
+	for _, p := range g.Players {
+		if p.Team == player.Team {
+			team = append(team, p)
+		}
+	}
+	return team
+}
+
+// loseLives takes n lives from the player's team and reports whether the player
+// is out of lives. In co-op running the shared pool dry ends the game instead of
+// eliminating the player.
+func (g *gameState) loseLives(player *Player, n int) bool {
+	for _, p := range g.teamOf(player) {
+		p.Lives -= n
+	}
+	if player.Lives > 0 {
+		return false
+	}
+	if g.isCoop() {
+		g.endGame(GameEndTeamDefeated)
+		return false
+	}
+	return true
+}
+
+// eliminateTeammates removes the rest of an eliminated player's team during the
+// night, and ends the game once a single team is left. The eliminated player
+// must still be in the game.
+func (g *gameState) eliminateTeammates(player *Player) {
+	if !g.isTeams() {
+		return
+	}
+
+	team := g.teamOf(player)
+	standing := TeamStanding{Team: player.Team}
+	for _, p := range team {
+		standing.Players = append(standing.Players, p.Name)
+	}
+	g.EliminatedTeams = append(g.EliminatedTeams, standing)
+
+	for _, p := range team {
+		if p == player {
+			continue
+		}
+		// A teammate still to be visited this round leaves one fewer player
+		// to visit; one already visited doesn't change what's left
+		offset := (slices.Index(g.Players, p) - g.CurrentPlayerIdx + len(g.Players)) % len(g.Players)
+		ahead := offset < g.NightPlayersToProcess-g.NightPlayerIndex
+		g.eliminatePlayer(p, "Team eliminated")
+		if ahead {
+			g.NightPlayersToProcess--
+		}
+	}
+
+	// The player hasn't been removed yet, so leave their team out
+	others := slices.DeleteFunc(g.teamsLeft(), func(team int) bool { return team == player.Team })
+	if len(others) == 1 {
+		g.endGame(GameEndLastTeamStanding)
+	}
+}
+
+// teamsLeft returns the teams that still have players, in seat order.
+func (g *gameState) teamsLeft() []int {
+	teams := []int{}
+	seen := map[int]bool{}
+	for _, p := range g.Players {
+		if !seen[p.Team] {
+			seen[p.Team] = true
+			teams = append(teams, p.Team)
+		}
+	}
+	return teams
+}
+
+// TeamStandings returns every team ranked by lives, most first. Teams still in
+// the game come before eliminated teams, which are ranked by how long they lasted.
+func (g *gameState) TeamStandings() []TeamStanding {
+	standings := []TeamStanding{}
+	for _, team := range g.teamsLeft() {
+		standing := TeamStanding{Team: team}
+		for _, p := range g.Players {
+			if p.Team == team {
+				standing.Players = append(standing.Players, p.Name)
+				standing.Lives = p.Lives
+			}
+		}
+		standings = append(standings, standing)
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Lives > standings[j].Lives
+	})
+
+	for i := len(g.EliminatedTeams) - 1; i >= 0; i-- {
+		standings = append(standings, g.EliminatedTeams[i])
+	}
+	return standings
+}
@@ -0,0 +1,63 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHandVisibility(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{Mode: ModeTeams}, "a", "b", "c", "d")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	for viewer := range 4 {
+		for owner := range 4 {
+			want := viewer%2 == owner%2
+			if got := view.HandVisibleTo(viewer, owner); got != want {
+				t.Errorf("seat %d seeing seat %d's hand: expected %v, got %v", viewer, owner, want, got)
+			}
+		}
+	}
+}
+
+// TestTeamEliminatedMidRound eliminates a team whose other player was already
+// visited this night, so the players after them must still be visited.
+func TestTeamEliminatedMidRound(t *testing.T) {
+	g := newTestGame(t, GameOptions{Mode: ModeTeams, Teams: []int{0, 1, 2, 0, 1, 2}}, "a", "b", "c", "d", "e", "f")
+	for _, player := range g.teamOf(g.Players[3]) {
+		player.Lives = 1
+	}
+	zombie := NightCards{{ZombieKey: 8}}
+
+	startNight(t, g, nil, nil, nil, zombie, zombie, zombie)
+	if g.CurrentPlayer().Name != "d" {
+		t.Fatalf("expected d to be attacked first, got %s", g.CurrentPlayer().Name)
+	}
+	g.ContinueAfterInput(0) // No defense
+	g.ContinueAfterInput(0) // Eliminated
+	if names := g.EliminatedTeams[0].Players; !slices.Equal(names, []string{"a", "d"}) {
+		t.Fatalf("expected a and d to go out together, got %v", names)
+	}
+
+	g.ContinueAfterInput(0) // e has no defense either
+	if g.CurrentPlayer().Name != "f" || g.NightPlayerIndex != 5 || g.NightPlayersToProcess != 6 {
+		t.Errorf("f should still be attacked in the first round, got %s on visit %d of %d",
+			g.CurrentPlayer().Name, g.NightPlayerIndex+1, g.NightPlayersToProcess)
+	}
+}
+
+func TestTeamGames(t *testing.T) {
+	for seed := range int64(20) {
+		g, _ := simulateGame(t, GameOptions{Mode: ModeTeams, Seed: seed + 1}, "a", "b", "c", "d")
+		if g.EndReason == GameEndLastTeamStanding && len(g.teamsLeft()) != 1 {
+			t.Errorf("expected one team left, got teams %v", g.teamsLeft())
+		}
+		for _, player := range g.Players {
+			for _, teammate := range g.teamOf(player) {
+				if teammate.Lives != player.Lives {
+					t.Errorf("%s and %s share lives, got %d and %d", player.Name, teammate.Name, player.Lives, teammate.Lives)
+				}
+			}
+		}
+	}
+}
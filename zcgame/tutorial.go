@@ -0,0 +1,88 @@
+package zcgame
+
+// Tutorial
+//
+// With GameOptions.Tutorial, the game explains a rule the first time it comes
+// up by attaching a tip to the next PlayerInputNeeded. Tips cover stacking Ammo
+// on a Shotgun, completing a Hay Wall, the Shield prompt against an Exploding
+// zombie and the first event.
+//
+// CreateTutorialGame deals TutorialLevel, a short solo game with a fixed deck
+// order that runs into every tip in its first two nights.
+
+// Tip is one tutorial explanation. Each tip is shown at most once per game.
+type Tip uint8
+
+const (
+	TipAmmoOnShotgun Tip = iota // A Shotgun and Ammo share a stack
+	TipHayWall                  // Three Hay Bales make a Hay Wall
+	TipShield                   // An Exploding zombie can be stopped with a Shield
+	TipEvent                    // A night card is an event rather than a zombie
+	NUM_TIPS                    // Sentinel value for bounds checking
+)
+
+// TutorialLevel is the guided game played by CreateTutorialGame. The deck
+// order is fixed so every player meets the rules in the same order.
+var TutorialLevel = Level{
+	ID:      "tutorial",
+	Name:    "Tutorial",
+	Intro:   "Zombie chickens come out at night. Build defenses on your farm during the day to fight them off.\nEach day you may discard a card to draw a new one, play 2 cards to your farm, then draw 2.\nTips will pop up the first time a new rule comes into play.",
+	Options: GameOptions{Tutorial: true, Seed: 1},
+	Farm:    Stacks{{Shield}},
+	Hand:    []FarmItemType{Shotgun, Ammo, HayBale, HayBale, Scarecrow},
+	Draws:   []FarmItemType{HayBale, Ammo, HayBale, Scarecrow},
+	Script: []string{
+		"Walker",
+		"Lightning Storm", "Chomper",
+		"Creeper", "Biter", "Floater",
+	},
+	Goal: LevelGoal{Nights: 3},
+}
+
+// CreateTutorialGame sets up the guided tutorial game for the given player name.
+func CreateTutorialGame(name string) (GameView, error) {
+	return CreateLevelGame(TutorialLevel, name)
+}
+
+// attachTip adds the first tip that applies and hasn't been shown yet to the
+// input, if the game is a tutorial.
+func (g *gameState) attachTip(input *PlayerInputNeeded) *PlayerInputNeeded {
+	if input == nil || !g.Options.Tutorial || !input.TipText.IsZero() {
+		return input
+	}
+	for tip := TipAmmoOnShotgun; tip < NUM_TIPS; tip++ {
+		if !g.TipsShown[tip] && g.tipApplies(tip, input) {
+			g.TipsShown[tip] = true
+			input.TipText = tip.Text()
+			break
+		}
+	}
+	return input
+}
+
+// tipApplies returns true if the rule the tip explains has just come up.
+func (g *gameState) tipApplies(tip Tip, input *PlayerInputNeeded) bool {
+	switch tip {
+	case TipAmmoOnShotgun:
+		return g.anyStack(func(s Stack) bool { return s.HasItem(Shotgun) && s.HasItem(Ammo) })
+	case TipHayWall:
+		return g.anyStack(func(s Stack) bool { return countItemInStack(s, HayBale) == 3 })
+	case TipShield:
+		return input.Context == InputContextShield
+	case TipEvent:
+		return g.Turn == Night && g.NightSubStage == NightSubStageEventConfirm
+	}
+	return false
+}
+
+// anyStack returns true if any stack on any farm matches.
+func (g *gameState) anyStack(match func(Stack) bool) bool {
+	for _, player := range g.Players {
+		for _, stack := range player.Farm.Stacks {
+			if match(stack) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTutorialTips(t *testing.T) {
+	view, err := CreateTutorialGame("solo")
+	if err != nil {
+		t.Fatalf("failed to create tutorial: %v", err)
+	}
+	if !slices.Equal(view.PublicDayCards(), PublicDayCards{TutorialLevel.Draws[0], TutorialLevel.Draws[1]}) {
+		t.Errorf("expected the first draws face up, got %s", view.PublicDayCards())
+	}
+
+	tips := map[string]int{}
+	cont, input := view.ContinueDay()
+	for steps := 0; cont || input != nil; steps++ {
+		if steps > 10000 {
+			t.Fatal("tutorial did not end")
+		}
+		if input == nil {
+			cont, input = view.ContinueDay()
+			continue
+		}
+		if input.Tip != "" {
+			tips[input.Tip]++
+		}
+		// Skip when possible, otherwise play the first choice; the tutorial's
+		// deck order makes every tip come up
+		choice := input.ValidChoices[0]
+		if slices.Contains(input.ValidChoices, 0) {
+			choice = 0
+		}
+		cont, input = view.ContinueAfterInput(choice)
+	}
+
+	for tip := TipAmmoOnShotgun; tip < NUM_TIPS; tip++ {
+		if tips[tip.Explanation()] != 1 {
+			t.Errorf("tip %d was shown %d times, expected once", tip, tips[tip.Explanation()])
+		}
+	}
+}
@@ -26,9 +26,10 @@ import (
 )
 
 // shuffle shuffles all elements of a slice in-place and returns the slice.
-// Uses the Fisher-Yates algorithm via rand.Shuffle.
-func shuffle[T any](slice []T) []T {
-	rand.Shuffle(len(slice), func(i, j int) {
+// Uses the Fisher-Yates algorithm via rand.Shuffle, drawing from the game's
+// rng so a seeded game always deals the same cards.
+func shuffle[T any](rng *rand.Rand, slice []T) []T {
+	rng.Shuffle(len(slice), func(i, j int) {
 		slice[i], slice[j] = slice[j], slice[i]
 	})
 	return slice
@@ -55,13 +56,46 @@ type PlayerPlayChoices struct {
 // Player represents a participant in the game with their current state.
 // Each player has lives (health), a hand of cards to play, and a farm to defend.
 type Player struct {
-	Name        string            // Display name (may include ANSI codes in CLI mode)
-	Lives       int               // Remaining lives; eliminated when <= 0
-	Farm        *Farm             // The player's farm with defensive stacks
-	Hand        Hand              // Current hand of 5 cards
-	PlayChoices PlayerPlayChoices // Automatic placement preferences
+	ID            PlayerID           // Stable identifier for the whole game, see PlayerID
+	Name          string             // Display name (may include ANSI codes in CLI mode)
+	Lives         int                // Remaining lives; eliminated when <= 0 (shared with teammates in co-op and team games)
+	Team          int                // Players on the same team share their lives (every player has their own team in competitive games)
+	Role          Role               // Farmer role with a special ability, or RoleNone
+	RoleUsed      bool               // Once-per-game role power has been spent
+	RoleUsedNight int                // Night number the nightly role power was last used
+	Farm          *Farm              // The player's farm with defensive stacks
+	Hand          Hand               // Current hand of Rules.HandSize cards
+	PlayChoices   PlayerPlayChoices  // Automatic placement preferences
+	NightChoices  PlayerNightChoices // Automatic answers to night prompts
+	ZombiesKilled int                // Zombie cards this player defeated
+	Elimination   *Elimination       // How the player went out of the game, nil while they are still in it
 }
 
+// Elimination records how a player went out of the game.
+type Elimination struct {
+	Night int    // Night the player went out on
+	Cause string // What took their last life: the zombie's name, or their team going out
+}
+
+// PlayerID identifies a player for the whole game. A player's index in Players
+// shifts as other players are eliminated, and names needn't be unique, but the
+// ID assigned when the game is created never changes and is never reused.
+// The zero value is no player.
+type PlayerID int
+
+// Role is a farmer character with a special ability, dealt at the start of a
+// game when GameOptions.Roles is enabled.
+type Role uint8
+
+const (
+	RoleNone  Role = iota // No role (roles are off)
+	Rancher               // Starts with a Hay Wall already built
+	Gunsmith              // Ammo isn't used up the first time it's used each night
+	Mechanic              // The first Booby Trap used stays on the farm (once per game)
+	Scavenger             // May take one face-up card and draw one from the deck
+	NUM_ROLES
+)
+
 // Turn represents the current phase of a game day.
 // A full day consists of Morning, Afternoon, and Night phases.
 type Turn uint8
@@ -70,7 +104,8 @@ const (
 	Morning   Turn = iota // First day phase: all players take turns
 	Afternoon             // Second day phase: all players take turns again
 	Night                 // Night phase: zombie attacks are resolved
-	Day                   // Reserved for future co-op mode
+	Day                   // Co-op only: between Afternoon and Night, players may give a card to a teammate
+	Setup                 // Before the first Morning: players choose their opening hands (see GameOptions.Setup)
 )
 
 // StageInTurn represents the current stage within a player's day turn.
@@ -79,20 +114,23 @@ type StageInTurn uint8
 
 const (
 	OptionalDiscard StageInTurn = iota // Player may discard one card to draw a replacement
-	Play2Cards                         // Player must play 2 cards from hand to farm
-	Draw2Cards                         // Player draws 2 cards (public or from deck)
+	Play2Cards                         // Player must play Rules.PlaysPerTurn cards from hand to farm
+	Draw2Cards                         // Player draws Rules.DrawsPerTurn cards (public or from deck)
 	Nighttime                          // Night phase processing (not a player turn stage)
+	ShareCards                         // Co-op Day phase: player may give a card to a teammate's farm
+	ChooseHand                         // Setup turn: player mulligans or drafts their opening hand
 )
 
 // Players is a slice of Player pointers representing all active players in the game.
 type Players []*Player
 
-// PublicDayCards represents the two face-up cards available for drawing.
-// Players can choose to draw these instead of drawing from the deck.
-type PublicDayCards [2]FarmItemType
+// PublicDayCards represents the face-up cards available for drawing
+// (Rules.PublicCards of them). Players can choose to draw these instead of
+// drawing from the deck.
+type PublicDayCards []FarmItemType
 
 func (p PublicDayCards) String() string {
-	return fmt.Sprintf("%s", Stack(p[:]))
+	return fmt.Sprintf("%s", Stack(p))
 }
 
 // DaySubStage tracks the current sub-stage within a player's day turn.
@@ -108,6 +146,14 @@ const (
 	DaySubStagePlay2                              // Waiting for second card selection
 	DaySubStagePlay2Stack                         // Waiting for stack selection for second card
 	DaySubStageDraw                               // Waiting for draw choice (public vs deck)
+	DaySubStageGiveCard                           // Co-op: waiting for a card to give, or skip
+	DaySubStageGiveTarget                         // Co-op: waiting for the teammate to give the card to
+	DaySubStageGiveRoom                           // Co-op: waiting for a teammate with a full hand to discard a card for the gift, or refuse it
+	DaySubStageActionTarget                       // Interaction: waiting for the player to play an action card on
+	DaySubStageSabotageItem                       // Interaction: waiting for the item to discard from the target's farm
+	DaySubStageTradeCard                          // Interaction: waiting for the hand card to give the target
+	DaySubStageMoveCard                           // Reorganize: waiting for the farm card to move
+	DaySubStageMoveStack                          // Reorganize: waiting for the stack to move the card to
 )
 
 // NightSubStage tracks the current sub-stage within the night phase.
@@ -125,8 +171,36 @@ const (
 	NightSubStageConfirmLifeLoss                       // Player chose to lose life; awaiting confirmation
 	NightSubStageEventConfirm                          // Event triggered; awaiting confirmation
 	NightSubStageEventDiscard                          // Event requires discards; awaiting selection
+	NightSubStageBossArrival                           // Boss arrived and applied its rule; awaiting confirmation
+	NightSubStageBossFight                             // Player must choose a stack to throw at the boss
+	NightSubStageBossDefeated                          // Boss was defeated; awaiting confirmation
+	NightSubStageBossOverrun                           // Boss overran the farm; awaiting life loss confirmation
+	NightSubStageLendDefense                           // Co-op: a neighbor may lend a stack; awaiting the neighbor's choice
+	NightSubStageBait                                  // Interaction: player may bait the zombie to an opponent
+)
+
+// GameEndReason records why a game ended.
+type GameEndReason uint8
+
+const (
+	GameNotOver             GameEndReason = iota // The game is still being played
+	GameEndAllEliminated                         // Every player was eliminated
+	GameEndDeckExhausted                         // A deck ran out under DeckExhaustionEndGame
+	GameEndMaxNights                             // GameOptions.MaxNights was played and one player (or team) had the most lives
+	GameEndTeamSurvived                          // Co-op: the team survived every night
+	GameEndTeamDefeated                          // Co-op: the team's shared lives ran out
+	GameEndLastTeamStanding                      // Teams: every other team was eliminated
+	GameEndLevelComplete                         // Campaign: the level's goal was met
+	GameEndLevelFailed                           // Campaign: the player has fewer lives than the level's goal asks for
 )
 
+// TeamStanding is one team's place in a team game.
+type TeamStanding struct {
+	Team    int      // Team number from GameOptions.Teams
+	Players []string // Names of the team's players, in seat order
+	Lives   int      // The team's shared lives, 0 once eliminated
+}
+
 // gameState is the central data structure containing all game state.
 // It tracks players, decks, turn progression, and state machine position.
 //
@@ -137,17 +211,39 @@ const (
 // This type is unexported. Use CreateNewGame to get a GameView, which
 // provides controlled access to the game state.
 type gameState struct {
+	Options GameOptions // Optional rules chosen at creation
+	Seed    int64       // Seed of rng; games with the same seed and options deal the same cards
+	rng     *rand.Rand  // Source of every shuffle and random pick in the game
+
+	undo      []undoPoint        // Copies of the game before each decision of the current day turn
+	lastInput *PlayerInputNeeded // The prompt waiting for an answer
+	Moves     []Move             // Every answer given so far, for the game record
+	DebugDeck bool               // The night deck was put in debug order by DebugEventsOnTop
+
 	// Core game state
 	Players             Players              // Active players (eliminated players are removed)
+	Eliminated          Players              // Players out of the game as they were when they went out, in order
 	CurrentPlayerIdx    int                  // Index of the player whose turn it is
 	StageInTurn         StageInTurn          // Current stage within a player's turn
 	Turn                Turn                 // Current phase of the day (Morning/Afternoon/Night)
 	DayDeck             Stack                // Draw pile for day cards
-	PublicDayCards      PublicDayCards       // Two face-up cards available for drawing
+	PublicDayCards      PublicDayCards       // Face-up cards available for drawing
 	DiscardedDayCards   map[FarmItemType]int // Discard pile counts by card type
 	NightDeck           NightCards           // Draw pile for night cards (zombies and events)
 	DiscardedNightCards NightCards           // Discarded night cards
 	NightNum            int                  // Current night number (increases each night)
+	EndReason           GameEndReason        // Why the game ended, or GameNotOver
+	FullDayDeck         Stack                // Every day card the game started with, for DeckExhaustionReshuffle
+	FullNightDeck       NightCards           // Every night card the game started with, for DeckExhaustionReshuffle
+	EliminatedTeams     []TeamStanding       // Teams: eliminated teams, in the order they went out
+	NightsSurvived      int                  // Nights that ended with a player still standing
+	ZombiesKilled       int                  // Zombie cards defeated, counted once each
+	CardsLeft           int                  // Solo: cards on the farm and in hand when the game ended
+	Level               *Level               // Campaign: the level being played, or nil
+	LevelKills          map[int]int          // Campaign: zombies killed, by ZombieChickens key
+	TipsShown           [NUM_TIPS]bool       // Tutorial: tips already attached to a prompt
+	DraftPool           Stack                // Draft: face-up cards left to pick opening hands from
+	DraftPick           int                  // Draft: picks made so far
 
 	// Day turn state machine fields
 	DaySubStage        DaySubStage   // Current sub-stage within day turn
@@ -155,6 +251,12 @@ type gameState struct {
 	PendingCardItem    FarmItemType  // Card awaiting stack selection
 	PendingStackChoice int           // Stack index chosen for card placement
 	PlayerTurnIndex    int           // Which player's turn in current phase (0 to len(Players)-1)
+	GiveTargetIdx      int           // Co-op: index of the teammate receiving PendingCardItem
+	ActionTargetIdx    int           // Interaction: index of the player an action card was played on
+	ActionPlayStage    DaySubStage   // Reorganize: the play stage a move was started from, returned to if it is cancelled
+	PlaysMade          int           // Cards played (or moves made) so far this turn
+	MoveFromStack      int           // Reorganize: stack the moved card was taken from, or -1 if it's gone
+	MoveFromIndex      int           // Reorganize: flat farm index the moved card was taken from
 
 	// Night phase state machine fields
 	NightCardsDealt       bool           // Whether night cards have been dealt this night
@@ -164,12 +266,14 @@ type gameState struct {
 	CurrentNightCard      *NightCard     // Night card currently being resolved
 	CurrentZombie         *ZombieChicken // Zombie currently attacking
 	ChosenStackIdx        int            // Stack index chosen for defense
+	LenderIdx             int            // Co-op: index of the neighbor asked to lend a stack
 
 	// Event discard state (for Lightning Storm, Tornado)
-	EventDiscardStartIdx  int // Player index when event was triggered
-	EventDiscardPlayerIdx int // Current player offset from start (0 to len(Players)-1)
-	EventDiscardRemaining int // Cards remaining to discard for current player
-	EventDiscardTotal     int // Total cards each player must discard
+	EventDiscardStartIdx  int   // Player index when event was triggered
+	EventDiscardPlayerIdx int   // Current player offset from start (0 to len(Players)-1)
+	EventDiscardRemaining int   // Cards remaining to discard for current player
+	EventDiscardTotal     int   // Total cards each player must discard
+	EventDiscardsLeft     []int // Simultaneous discards: cards each player still has to discard, nil when taking turns
 
 	// Pending event display state
 	PendingEventName string // Event name saved for confirmation display
@@ -177,6 +281,9 @@ type gameState struct {
 
 	// Defense display state
 	LastUsedDefenseDesc string // Description of defense used (e.g., "Scarecrow", "Hay Wall")
+
+	// Boss display state
+	BossArrival Text // What the boss did on arrival, saved for confirmation display
 }
 
 // ZombieTrait represents a special ability that a zombie chicken can have.
@@ -191,6 +298,10 @@ const (
 	Fireproof                            // Overcomes: Flamethrower (immune to fire)
 	Timid                                // Weakness: Frightened by Scarecrows
 	Exploding                            // Destroys the stack used to defeat it (unless Shield is used)
+	Armored                              // Expansion: must be defended twice in one night to be killed
+	Swarm                                // Expansion: attacks twice
+	Burrowing                            // Expansion: Overcomes: Booby Trap (tunnels under it)
+	Contagious                           // Expansion: an undefended attack infects the next player with another zombie
 	NUM_ZOMBIE_TRAITS                    // Sentinel value for bounds checking
 )
 
@@ -204,8 +315,25 @@ type ZombieChicken struct {
 	Traits    ZombieTraits // Special abilities this zombie has
 	NumInDeck int8         // How many of this zombie type exist in the night deck
 	Name      string       // Display name for this zombie type
+	Expansion bool         // Only in the night deck when GameOptions.ZombieExpansion is enabled
+	Health    int8         // Bosses only: stacks needed to defeat it (0 for regular zombies)
+	BossRule  BossRule     // Bosses only: special rule applied when the boss arrives
+}
+
+// IsBoss returns true if this zombie is a boss rather than a regular night deck zombie.
+func (z ZombieChicken) IsBoss() bool {
+	return z.Health > 0
 }
 
+// BossRule is a special rule a boss applies to the player's farm when it arrives.
+type BossRule uint8
+
+const (
+	BossRuleNone  BossRule = iota // No arrival rule
+	BossRuleWreck                 // Destroys the player's weakest stack (fewest items)
+	BossRuleHatch                 // Lays another zombie behind it in the player's night cards
+)
+
 // Event represents a special night card that affects all players.
 // Unlike zombie cards, events trigger global effects such as forcing
 // discards or adding extra night cards.
@@ -239,9 +367,7 @@ var NightCardEvents = []Event{
 		Action: func(g *gameState) *PlayerInputNeeded {
 			for i := range g.Players {
 				idx := (g.CurrentPlayerIdx + i) % len(g.Players)
-				for range 3 {
-					g.Players[idx].Farm.NightCards = append(g.Players[idx].Farm.NightCards, g.nextNightCard())
-				}
+				g.dealNightCards(g.Players[idx], 3)
 			}
 			return nil
 		},
@@ -252,8 +378,7 @@ var NightCardEvents = []Event{
 		Action: func(g *gameState) *PlayerInputNeeded {
 			for i := range g.Players {
 				idx := (g.CurrentPlayerIdx + i) % len(g.Players)
-				g.Players[idx].Farm.NightCards = append(g.Players[idx].Farm.NightCards, g.nextNightCard())
-				g.Players[idx].Farm.NightCards = append(g.Players[idx].Farm.NightCards, g.nextNightCard())
+				g.dealNightCards(g.Players[idx], 2)
 			}
 			return nil
 		},
@@ -323,6 +448,7 @@ var NightCardEvents = []Event{
 type NightCard struct {
 	Event     Event // Event data (only valid when ZombieKey == -1)
 	ZombieKey int   // Index into ZombieChickens map, or -1 for events
+	Resolved  int   // Attacks already resolved from this card (Armored and Swarm zombies attack more than once)
 }
 
 // NightCards is a slice of NightCard, used for decks and discard piles.
@@ -356,6 +482,14 @@ const (
 	Flamethrower                       //  6 in deck | Combine with Fuel to roast a zombie
 	Fuel                               //  6 in deck | Combine with Flamethrower to roast a zombie
 	WOLR                               //  4 in deck | Destroys 1 zombie plus entire farm (one-time-use)
+	Pitchfork                          //  6 in deck | Expansion: stabs a ground zombie, wears out after 2 uses
+	GuardDog                           //  4 in deck | Expansion: sniffs out Invisible zombies for an adjacent Shotgun
+	ElectricFence                      //  4 in deck | Expansion: combine with Generator to zap a ground zombie
+	Generator                          //  4 in deck | Expansion: combine with Electric Fence
+	WornPitchfork                      //  0 in deck | A Pitchfork after its first use (one-time-use)
+	Bait                               //  4 in deck | Interaction: send an attacking zombie to an opponent (one-time-use)
+	Sabotage                           //  4 in deck | Interaction: discard an item from an opponent's farm (action)
+	Trade                              //  4 in deck | Interaction: swap a hand card with a neighbor (action)
 	NUM_FARM_ITEMS                     // Sentinel value for bounds checking and empty hand slots
 )
 
@@ -363,7 +497,7 @@ const (
 // One-time-use items are discarded after defeating a zombie.
 func (f FarmItemType) IsOneTimeUse() bool {
 	switch f {
-	case Ammo, WOLR, BoobyTrap, Shield:
+	case Ammo, WOLR, BoobyTrap, Shield, WornPitchfork, Bait:
 		return true
 	default:
 		return false
@@ -382,6 +516,16 @@ var CanBeStackedWithLookup = map[FarmItemType]Stack{
 	Flamethrower: {Fuel},          // Can be placed on Fuel
 	Fuel:         {Flamethrower},  // Can be placed on Flamethrower
 	WOLR:         {},              // Must be alone
+
+	// Defense expansion
+	Pitchfork:     {},              // Must be alone
+	GuardDog:      {},              // Must be alone
+	ElectricFence: {Generator},     // Can be placed on Generator
+	Generator:     {ElectricFence}, // Can be placed on Electric Fence
+	WornPitchfork: {},              // Must be alone
+
+	// Interaction cards (Sabotage and Trade never reach the farm)
+	Bait: {}, // Must be alone
 }
 
 // DayCardAmounts defines how many of each card type exist in the day deck.
@@ -397,6 +541,23 @@ var DayCardAmounts = map[FarmItemType]int{
 	WOLR:         4,
 }
 
+// ExpansionDayCardAmounts defines how many of each defense expansion card are
+// added to the day deck when GameOptions.DefenseExpansion is enabled.
+var ExpansionDayCardAmounts = map[FarmItemType]int{
+	Pitchfork:     6,
+	GuardDog:      4,
+	ElectricFence: 4,
+	Generator:     4,
+}
+
+// InteractionDayCardAmounts defines how many of each interaction card are
+// added to the day deck when GameOptions.InteractionCards is enabled.
+var InteractionDayCardAmounts = map[FarmItemType]int{
+	Bait:     4,
+	Sabotage: 4,
+	Trade:    4,
+}
+
 // Stack is a collection of FarmItemType cards that form a single defense.
 // Valid stacks follow specific rules (e.g., Shotgun+Ammo, 3 HayBales for a wall).
 // Use Farm.PlayCard to add cards to stacks with automatic rule enforcement.
@@ -466,15 +627,15 @@ func (s Stack) Sort() {
 // remaining cards by FarmItemType value (ascending).
 //
 // Empty slots (FarmItemType == NUM_FARM_ITEMS) are always placed at the end.
-// This ensures that hand indices 0-2 (or 0-4 when full) contain valid cards,
-// which is important for the game loop that accesses slots [3] and [4] after drawing.
+// This ensures that the first Count slots contain valid cards, which is
+// important for choices, which offers exactly those slots.
 //
 // Example:
 //
 //	hand := Hand{{Ammo, false}, {NUM_FARM_ITEMS, false}, {HayBale, false}, ...}
 //	hand.Sort()
 //	// Result: {{HayBale, false}, {Ammo, false}, {NUM_FARM_ITEMS, false}, ...}
-func (h *Hand) Sort() {
+func (h Hand) Sort() {
 	// Move NUM_FARM_ITEMS (blank slots) to the end, then sort by FarmItemType
 	for i := 0; i < len(h); i++ {
 		for j := i + 1; j < len(h); j++ {
@@ -508,9 +669,54 @@ type HandItem struct {
 	Visible      bool         // Whether this card is visible to other players (for frontend)
 }
 
-// Hand is a fixed-size array of 5 HandItem slots representing a player's hand.
-// Empty slots have FarmItemType set to NUM_FARM_ITEMS.
-type Hand [5]HandItem
+// Hand holds Rules.HandSize HandItem slots representing a player's hand.
+// Empty slots have FarmItemType set to NUM_FARM_ITEMS. The number of slots
+// never changes during a game.
+type Hand []HandItem
+
+// newHand returns a hand of the given size with every slot empty.
+func newHand(size int) Hand {
+	h := make(Hand, size)
+	for i := range h {
+		h[i] = HandItem{FarmItemType: NUM_FARM_ITEMS}
+	}
+	return h
+}
+
+// Count returns the number of cards in the hand (empty slots are not counted).
+func (h Hand) Count() int {
+	count := 0
+	for _, item := range h {
+		if item.FarmItemType != NUM_FARM_ITEMS {
+			count++
+		}
+	}
+	return count
+}
+
+// choices returns the 1-based hand indices a player can pick from.
+// The hand must be sorted so that cards come before empty slots.
+func (h Hand) choices() []int {
+	choices := make([]int, h.Count())
+	for i := range choices {
+		choices[i] = i + 1
+	}
+	return choices
+}
+
+// add puts a card in the first empty slot of the hand. Drawing nothing
+// (NUM_FARM_ITEMS) or drawing into a full hand leaves the hand unchanged.
+func (h Hand) add(item FarmItemType) {
+	if item == NUM_FARM_ITEMS {
+		return
+	}
+	for i := range h {
+		if h[i].FarmItemType == NUM_FARM_ITEMS {
+			h[i] = HandItem{FarmItemType: item}
+			return
+		}
+	}
+}
 
 // Farm represents a player's defensive area containing stacks of cards
 // and pending night cards (zombie attacks) to resolve.
@@ -523,18 +729,30 @@ type Farm struct {
 // Returns the removed item type, or NUM_FARM_ITEMS if index is out of bounds.
 // This is called internally by the state machine with pre-validated indices from event discards.
 func (f *Farm) RemoveItemByFlatIndex(flatIdx int, g *gameState) FarmItemType {
+	item, _ := f.takeItemByFlatIndex(flatIdx)
+	g.discardDayCard(item)
+	return item
+}
+
+// takeItemByFlatIndex takes an item off the farm by its flat index without
+// discarding it. Returns the item and the index of the stack it came from, or
+// -1 if that stack is now empty and gone. Returns NUM_FARM_ITEMS and -1 if the
+// index is out of bounds.
+func (f *Farm) takeItemByFlatIndex(flatIdx int) (FarmItemType, int) {
 	idx := 0
 	for i := range f.Stacks {
 		for j := range f.Stacks[i] {
 			if idx == flatIdx {
 				item := f.Stacks[i][j]
 				f.Stacks[i] = append(f.Stacks[i][:j], f.Stacks[i][j+1:]...)
-				f.clearStacks()
-				g.discardDayCard(item)
-				return item
+				if len(f.Stacks[i]) == 0 {
+					f.clearStacks()
+					return item, -1
+				}
+				return item, i
 			}
 			idx++
 		}
 	}
-	return NUM_FARM_ITEMS
+	return NUM_FARM_ITEMS, -1
 }
@@ -0,0 +1,115 @@
+package zcgame
+
+// Undo
+//
+// A player can take back decisions made during their own day turn. Before each
+// day decision is applied, the game keeps a copy of its state along with the
+// prompt that was answered; Undo puts the latest copy back and asks the prompt
+// again. Repeated undos rewind to the start of the turn.
+//
+// Copies are dropped as soon as hidden information comes out - a card drawn
+// from the deck or taken at random from a hand - and when the turn passes to
+// the next player, so nobody can undo their way into knowledge they shouldn't
+// have.
+
+import (
+	"maps"
+	"slices"
+)
+
+// undoLimit is the most decisions that can be undone in a row.
+const undoLimit = 10
+
+// undoPoint is the game as it was before a decision, and the prompt that decision answered.
+type undoPoint struct {
+	state *gameState
+	input *PlayerInputNeeded
+}
+
+// CanUndo returns true if the player answering the current prompt can take
+// back their last decision.
+func (g *gameState) CanUndo() bool {
+	return len(g.undo) > 0 && g.EndReason == GameNotOver
+}
+
+// Undo rewinds the game to before the last decision of the current day turn
+// and returns the prompt to answer again. Returns nil if there is nothing to undo.
+func (g *gameState) Undo() *PlayerInputNeeded {
+	if !g.CanUndo() {
+		return nil
+	}
+	point := g.undo[len(g.undo)-1]
+	undo := g.undo[:len(g.undo)-1]
+	*g = *point.state
+	g.undo = undo
+	g.lastInput = point.input
+	return point.input
+}
+
+// saveUndo keeps a copy of the game before a day decision is applied.
+func (g *gameState) saveUndo() {
+	if g.lastInput == nil || (g.Turn != Morning && g.Turn != Afternoon && g.Turn != Day) {
+		return
+	}
+	g.undo = append(g.undo, undoPoint{state: g.clone(), input: g.lastInput})
+	if len(g.undo) > undoLimit {
+		g.undo = g.undo[1:]
+	}
+}
+
+// clearUndo drops every saved copy; called once hidden information is revealed
+// or the turn moves on.
+func (g *gameState) clearUndo() {
+	g.undo = nil
+}
+
+// clone returns a deep copy of the game without its undo history. The full
+// decks and campaign level are never changed during a game, and the rng is
+// only used once undo is no longer possible, so they are shared.
+func (g *gameState) clone() *gameState {
+	c := *g
+	c.undo = nil
+	c.Options.Teams = slices.Clone(g.Options.Teams)
+	c.Players = make(Players, len(g.Players))
+	for i, player := range g.Players {
+		c.Players[i] = player.clone()
+	}
+	// Eliminated players are never changed, so their copies can be shared
+	c.Eliminated = slices.Clone(g.Eliminated)
+	c.DayDeck = slices.Clone(g.DayDeck)
+	c.PublicDayCards = slices.Clone(g.PublicDayCards)
+	c.DiscardedDayCards = maps.Clone(g.DiscardedDayCards)
+	c.NightDeck = slices.Clone(g.NightDeck)
+	c.DiscardedNightCards = slices.Clone(g.DiscardedNightCards)
+	c.EliminatedTeams = slices.Clone(g.EliminatedTeams)
+	for i := range c.EliminatedTeams {
+		c.EliminatedTeams[i].Players = slices.Clone(g.EliminatedTeams[i].Players)
+	}
+	c.LevelKills = maps.Clone(g.LevelKills)
+	c.DraftPool = slices.Clone(g.DraftPool)
+	c.EventDiscardsLeft = slices.Clone(g.EventDiscardsLeft)
+	c.Moves = slices.Clone(g.Moves)
+	if g.CurrentNightCard != nil {
+		card := *g.CurrentNightCard
+		c.CurrentNightCard = &card
+	}
+	if g.CurrentZombie != nil {
+		zombie := *g.CurrentZombie
+		c.CurrentZombie = &zombie
+	}
+	return &c
+}
+
+// clone returns a deep copy of the player and their farm.
+func (p *Player) clone() *Player {
+	c := *p
+	c.Hand = slices.Clone(p.Hand)
+	c.Farm = &Farm{
+		Stacks:     make(Stacks, len(p.Farm.Stacks)),
+		NightCards: slices.Clone(p.Farm.NightCards),
+	}
+	for i, stack := range p.Farm.Stacks {
+		c.Farm.Stacks[i] = slices.Clone(stack)
+	}
+	return &c
+}
@@ -0,0 +1,35 @@
+package zcgame
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	view, err := CreateNewGameWithOptions(GameOptions{Seed: 5}, "p1", "p2")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	_, discard := view.ContinueDay()
+	hand := view.Player(0).Hand()
+	_, play := view.ContinueAfterInput(0) // Skip the discard
+	view.ContinueAfterInput(play.ValidChoices[0])
+	if slices.Equal(view.Player(0).Hand(), hand) {
+		t.Fatal("expected the first play to leave the hand")
+	}
+
+	if input := view.Undo(); input != play || !slices.Equal(view.Player(0).Hand(), hand) || len(view.Player(0).Stacks()) != 0 {
+		t.Errorf("expected undo to return to the play prompt with the card back in hand, got %q", input.Message)
+	}
+	if input := view.Undo(); input != discard {
+		t.Errorf("expected a second undo to return to the discard prompt, got %q", input.Message)
+	}
+	if view.CanUndo() || view.Undo() != nil {
+		t.Error("expected nothing left to undo at the start of the turn")
+	}
+
+	view.ContinueAfterInput(1) // Discarding draws a card from the deck
+	if view.CanUndo() {
+		t.Error("expected no undo once a card was drawn from the deck")
+	}
+}
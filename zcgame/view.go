@@ -27,6 +27,45 @@ func (v GameView) ContinueAfterInput(choice int) (bool, *PlayerInputNeeded) {
 	return v.game.ContinueAfterInput(choice)
 }
 
+// ContinueAfterInputs answers a multi-select prompt with all of its choices.
+// See gameState.ContinueAfterInputs for full documentation.
+func (v GameView) ContinueAfterInputs(choices []int) (bool, *PlayerInputNeeded) {
+	return v.game.ContinueAfterInputs(choices)
+}
+
+// ContinueAfterInputFrom answers the prompt waiting on the given player.
+// See gameState.ContinueAfterInputFrom for full documentation.
+func (v GameView) ContinueAfterInputFrom(playerIdx int, choice int) (bool, *PlayerInputNeeded) {
+	return v.game.ContinueAfterInputFrom(playerIdx, choice)
+}
+
+// ContinueAfterInputsFrom answers the multi-select prompt waiting on the given player.
+// See gameState.ContinueAfterInputsFrom for full documentation.
+func (v GameView) ContinueAfterInputsFrom(playerIdx int, choices []int) (bool, *PlayerInputNeeded) {
+	return v.game.ContinueAfterInputsFrom(playerIdx, choices)
+}
+
+// SetNightChoices sets how the player at the given index answers night prompts.
+// Does nothing if the index is out of bounds.
+func (v GameView) SetNightChoices(idx int, choices PlayerNightChoices) {
+	if idx < 0 || idx >= len(v.game.Players) {
+		return
+	}
+	v.game.Players[idx].NightChoices = choices
+}
+
+// CanUndo returns true if the player answering the current prompt can take
+// back their last decision this turn.
+func (v GameView) CanUndo() bool {
+	return v.game.CanUndo()
+}
+
+// Undo rewinds to before the last decision of the current day turn.
+// See gameState.Undo for full documentation.
+func (v GameView) Undo() *PlayerInputNeeded {
+	return v.game.Undo()
+}
+
 // DebugEventsOnTop moves event cards to top of night deck for testing.
 func (v GameView) DebugEventsOnTop() {
 	v.game.DebugEventsOnTop()
@@ -34,6 +73,46 @@ func (v GameView) DebugEventsOnTop() {
 
 // --- Read-Only Accessors (return copies, not pointers) ---
 
+// Options returns the options the game was created with.
+func (v GameView) Options() GameOptions {
+	options := v.game.Options
+	if options.Teams != nil {
+		options.Teams = append([]int(nil), options.Teams...)
+	}
+	return options
+}
+
+// Seed returns the seed the game's shuffles were made with. Creating a game
+// with the same seed and options deals the same cards.
+func (v GameView) Seed() int64 {
+	return v.game.Seed
+}
+
+// SoloScore returns the score of a solo game: final once the game is over,
+// otherwise the score so far.
+func (v GameView) SoloScore() SoloScore {
+	return v.game.SoloScore()
+}
+
+// Level returns a copy of the campaign level being played, or nil if the game
+// isn't a campaign level.
+func (v GameView) Level() *Level {
+	if v.game.Level == nil {
+		return nil
+	}
+	level := *v.game.Level
+	return &level
+}
+
+// LevelProgress returns how far the campaign level's goal has been met.
+// It is the zero value if the game isn't a campaign level.
+func (v GameView) LevelProgress() LevelProgress {
+	if v.game.Level == nil {
+		return LevelProgress{}
+	}
+	return v.game.levelProgress()
+}
+
 // Turn returns the current turn phase.
 func (v GameView) Turn() Turn {
 	return v.game.Turn
@@ -44,6 +123,11 @@ func (v GameView) NightNum() int {
 	return v.game.NightNum
 }
 
+// IsBossNight returns true if every player faces a boss on the current night.
+func (v GameView) IsBossNight() bool {
+	return v.game.isBossNight()
+}
+
 // StageInTurn returns the current stage within the turn.
 func (v GameView) StageInTurn() StageInTurn {
 	return v.game.StageInTurn
@@ -58,16 +142,32 @@ func (v GameView) CurrentPlayerIdx() int {
 // This differs from CurrentPlayerIdx during event discards, where multiple players
 // take turns discarding but CurrentPlayerIdx stays on the player who drew the event.
 func (v GameView) ActiveInputPlayerIdx() int {
-	// During event discards, calculate the actual player who needs to discard
-	if v.game.NightSubStage == NightSubStageEventDiscard {
-		return (v.game.EventDiscardStartIdx + v.game.EventDiscardPlayerIdx) % len(v.game.Players)
-	}
-	return v.game.CurrentPlayerIdx
+	return v.game.activeInputPlayerIdx()
+}
+
+// PendingInputs returns the prompts waiting to be answered, keyed by player index.
+// See gameState.PendingInputs for full documentation.
+func (v GameView) PendingInputs() map[int]*PlayerInputNeeded {
+	return v.game.PendingInputs()
+}
+
+// StackChoicePlayerIdx returns the index of the player whose farm the pending
+// stack choices refer to. This is usually ActiveInputPlayerIdx, but in co-op
+// a player giving a card picks a stack on the teammate's farm, and a player
+// playing Sabotage picks an item on the target's farm.
+func (v GameView) StackChoicePlayerIdx() int {
+	return v.game.stackChoicePlayerIdx()
+}
+
+// DraftPool returns a copy of the face-up cards left to pick from during a
+// draft. It is empty outside the Setup turn.
+func (v GameView) DraftPool() Stack {
+	return append(Stack{}, v.game.DraftPool...)
 }
 
 // PublicDayCards returns a copy of the public day cards.
 func (v GameView) PublicDayCards() PublicDayCards {
-	return v.game.PublicDayCards // Already a value type [2]FarmItemType
+	return append(PublicDayCards{}, v.game.PublicDayCards...)
 }
 
 // DayDeckCount returns the number of cards remaining in the day deck.
@@ -124,8 +224,44 @@ func (v GameView) CurrentPlayer() PlayerView {
 	return v.Player(v.game.CurrentPlayerIdx)
 }
 
+// PlayerIdxByID returns the current index of the player with the given ID, or
+// -1 if they have been eliminated or there is no such player.
+func (v GameView) PlayerIdxByID(id PlayerID) int {
+	for i, p := range v.game.Players {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// PlayerByID returns the player with the given ID, whether still playing or
+// eliminated (as they were when they went out). The bool is false if there is
+// no such player.
+func (v GameView) PlayerByID(id PlayerID) (PlayerView, bool) {
+	if idx := v.PlayerIdxByID(id); idx != -1 {
+		return v.Player(idx), true
+	}
+	for _, p := range v.game.Eliminated {
+		if p.ID == id {
+			return PlayerView{player: p}, true
+		}
+	}
+	return PlayerView{}, false
+}
+
+// Eliminated returns the players who are out of the game, as they were when
+// they went out, in the order they were eliminated.
+func (v GameView) Eliminated() []PlayerView {
+	result := make([]PlayerView, len(v.game.Eliminated))
+	for i, p := range v.game.Eliminated {
+		result[i] = PlayerView{player: p}
+	}
+	return result
+}
+
 // PlayerIdxByName returns the current index of a player by name, or -1 if not found.
-// This is useful when players can be eliminated and indices shift.
+// Names needn't be unique, so prefer PlayerIdxByID.
 func (v GameView) PlayerIdxByName(name string) int {
 	for i, p := range v.game.Players {
 		if p.Name == name {
@@ -140,6 +276,46 @@ func (v GameView) HasLivingPlayers() bool {
 	return v.game.HasLivingPlayers()
 }
 
+// EndReason returns why the game ended, or GameNotOver while it is being played.
+func (v GameView) EndReason() GameEndReason {
+	return v.game.EndReason
+}
+
+// Standings returns the remaining players ranked by lives, most first.
+// Once a game ends with players still standing, the first one is the winner.
+func (v GameView) Standings() []PlayerView {
+	standings := v.game.Standings()
+	result := make([]PlayerView, len(standings))
+	for i, p := range standings {
+		result[i] = PlayerView{player: p}
+	}
+	return result
+}
+
+// TeamStandings returns every team ranked by lives, most first, with eliminated
+// teams last. Once a team game ends, the first team is the winner.
+// Only team games have more than one player per team.
+func (v GameView) TeamStandings() []TeamStanding {
+	standings := v.game.TeamStandings()
+	for i := range standings {
+		standings[i].Players = append([]string(nil), standings[i].Players...)
+	}
+	return standings
+}
+
+// HandVisibleTo returns true if the viewer may see the owner's hand.
+// In team games opponents' hands are hidden; teammates see each other's.
+// A viewer of -1 (someone not in the game) sees no hands in team games.
+func (v GameView) HandVisibleTo(viewerIdx int, ownerIdx int) bool {
+	if !v.game.isTeams() {
+		return true
+	}
+	if viewerIdx < 0 || viewerIdx >= len(v.game.Players) || ownerIdx < 0 || ownerIdx >= len(v.game.Players) {
+		return false
+	}
+	return v.game.Players[viewerIdx].Team == v.game.Players[ownerIdx].Team
+}
+
 // --- PlayerView ---
 
 // PlayerView wraps a Player and provides read-only access.
@@ -149,6 +325,31 @@ type PlayerView struct {
 	player *Player
 }
 
+// ID returns the player's stable ID, or 0 for an empty PlayerView.
+func (pv PlayerView) ID() PlayerID {
+	if pv.player == nil {
+		return 0
+	}
+	return pv.player.ID
+}
+
+// ZombiesKilled returns how many zombie cards the player has defeated.
+func (pv PlayerView) ZombiesKilled() int {
+	if pv.player == nil {
+		return 0
+	}
+	return pv.player.ZombiesKilled
+}
+
+// Elimination returns when and how the player went out of the game. The bool
+// is false while they are still in it.
+func (pv PlayerView) Elimination() (Elimination, bool) {
+	if pv.player == nil || pv.player.Elimination == nil {
+		return Elimination{}, false
+	}
+	return *pv.player.Elimination, true
+}
+
 // Name returns the player's display name.
 func (pv PlayerView) Name() string {
 	if pv.player == nil {
@@ -157,6 +358,39 @@ func (pv PlayerView) Name() string {
 	return pv.player.Name
 }
 
+// Team returns the player's team number. Only team games share teams
+// between players; in co-op everyone is on team 0.
+func (pv PlayerView) Team() int {
+	if pv.player == nil {
+		return 0
+	}
+	return pv.player.Team
+}
+
+// Role returns the player's farmer role, or RoleNone when roles are off.
+func (pv PlayerView) Role() Role {
+	if pv.player == nil {
+		return RoleNone
+	}
+	return pv.player.Role
+}
+
+// RoleUsed returns true once the player's once-per-game role power is spent.
+func (pv PlayerView) RoleUsed() bool {
+	if pv.player == nil {
+		return false
+	}
+	return pv.player.RoleUsed
+}
+
+// NightChoices returns how the player answers night prompts automatically.
+func (pv PlayerView) NightChoices() PlayerNightChoices {
+	if pv.player == nil {
+		return PlayerNightChoices{}
+	}
+	return pv.player.NightChoices
+}
+
 // Lives returns the player's remaining lives.
 func (pv PlayerView) Lives() int {
 	if pv.player == nil {
@@ -170,7 +404,7 @@ func (pv PlayerView) Hand() Hand {
 	if pv.player == nil {
 		return Hand{}
 	}
-	return pv.player.Hand // Already a value type [5]HandItem
+	return append(Hand{}, pv.player.Hand...)
 }
 
 // Stacks returns a deep copy of the player's farm stacks.
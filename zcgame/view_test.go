@@ -0,0 +1,34 @@
+package zcgame
+
+import "testing"
+
+func TestPlayerIDs(t *testing.T) {
+	view, err := CreateNewGame("Sam", "Sam", "Kit")
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	g := view.game
+	second := view.Player(1).ID()
+	kit := view.Player(2).ID()
+	if second == view.Player(0).ID() {
+		t.Fatal("expected players with the same name to get different IDs")
+	}
+
+	g.Players[1].Farm.Stacks = Stacks{{Scarecrow}}
+	g.Players[1].Lives = 0
+	g.eliminatePlayer(g.Players[1], "Test")
+
+	if view.PlayerIdxByID(kit) != 1 || view.PlayerIdxByID(second) != -1 {
+		t.Errorf("expected Kit to move up to index 1 and the second Sam to be gone, got %d and %d", view.PlayerIdxByID(kit), view.PlayerIdxByID(second))
+	}
+	pv, ok := view.PlayerByID(second)
+	if !ok || pv.Name() != "Sam" || pv.Stacks().String() != (Stacks{{Scarecrow}}).String() {
+		t.Errorf("expected the second Sam to be found with their final farm, got %v %q %s", ok, pv.Name(), pv.Stacks())
+	}
+	if eliminated := view.Eliminated(); len(eliminated) != 1 || eliminated[0].ID() != second {
+		t.Errorf("expected the second Sam to be the only one eliminated, got %d", len(eliminated))
+	}
+	if out, ok := pv.Elimination(); !ok || out.Night != 1 || out.Cause != "Test" {
+		t.Errorf("expected the second Sam to have gone out on night 1 to Test, got %+v", out)
+	}
+}